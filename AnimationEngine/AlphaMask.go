@@ -0,0 +1,81 @@
+package AnimationEngine
+
+/*
+AlphaMask.go - Per-pixel opacity masks for hit testing
+
+Textures live on the GPU, so a 1-bit mask of opaque pixels is kept alongside
+each frame while its image is still in memory. Pixels with alpha at or below
+alphaThreshold count as transparent.
+
+Functions:
+- maskFromNRGBA: Build a mask from a decoded frame image
+- (AlphaMask) Opaque: Check if the pixel at x, y is opaque
+- (AnimationPlayer) IsOpaqueAt: Check if the current frame is opaque at a window-relative point
+*/
+
+import (
+	"image"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+const alphaThreshold = 16
+
+type AlphaMask struct {
+	W, H int32
+	bits []uint8
+}
+
+func newAlphaMask(w, h int32) *AlphaMask {
+	return &AlphaMask{W: w, H: h, bits: make([]uint8, (int(w)*int(h)+7)/8)}
+}
+
+func (m *AlphaMask) set(x, y int32) {
+	i := int(y)*int(m.W) + int(x)
+	m.bits[i/8] |= 1 << (i % 8)
+}
+
+func (m *AlphaMask) Opaque(x, y int32) bool {
+	if m == nil || x < 0 || y < 0 || x >= m.W || y >= m.H {
+		return false
+	}
+	i := int(y)*int(m.W) + int(x)
+	return m.bits[i/8]&(1<<(i%8)) != 0
+}
+
+func maskFromNRGBA(img *image.NRGBA) *AlphaMask {
+	size := img.Bounds().Size()
+	mask := newAlphaMask(int32(size.X), int32(size.Y))
+	for y := 0; y < size.Y; y++ {
+		row := img.Pix[y*img.Stride:]
+		for x := 0; x < size.X; x++ {
+			if row[x*4+3] > alphaThreshold {
+				mask.set(int32(x), int32(y))
+			}
+		}
+	}
+	return mask
+}
+
+// IsOpaqueAt takes a point normalized to the window size (0..1) so it works
+// at any scale. Frames without a mask are treated as fully opaque.
+func (ap *AnimationPlayer) IsOpaqueAt(nx, ny float64) bool {
+	if ap.currentFrame >= len(ap.masks) || ap.masks[ap.currentFrame] == nil {
+		return true
+	}
+
+	orig := ap.originalSizes[ap.currentFrame]
+	if ap.flip == sdl.FlipHorizontal {
+		nx = 1 - nx
+	}
+	x := int32(nx * float64(orig.X))
+	y := int32(ny * float64(orig.Y))
+
+	// Sprite-sheet frames share the sheet's mask
+	if ap.currentFrame < len(ap.srcRects) {
+		src := ap.srcRects[ap.currentFrame]
+		x += int32(src.X)
+		y += int32(src.Y)
+	}
+	return ap.masks[ap.currentFrame].Opaque(x, y)
+}
@@ -5,91 +5,270 @@ Animation.go - Core animation player for character sprites
 
 Functions:
 - NewAnimationPlayer: Create new animation player instance
-- (AnimationPlayer) LoadFrames: Load PNG frames from directory into textures
-- (AnimationPlayer) Update: Advance animation frame based on timing
-- (AnimationPlayer) Render: Render current frame to renderer
-- (AnimationPlayer) SetScale: Adjust character scale
+- (AnimationPlayer) LoadFrames: Load PNG/JPG frames and animation.json from directory
+- (AnimationPlayer) GetManifest: Get the character's animation.json settings
+- (AnimationPlayer) SetState: Switch the active animation state
+- (AnimationPlayer) GetState: Get the active animation state name
+- (AnimationPlayer) States: List loaded animation state names
+- (AnimationPlayer) Update: Advance animation frame based on timing and loop mode
+- (AnimationPlayer) SetLoopMode: Choose looping, play-once or ping-pong playback
+- (AnimationPlayer) IsFinished: Check if a play-once animation reached its last frame
+- (AnimationPlayer) SetReverse: Play frames backwards
+- (AnimationPlayer) Pause / Resume / IsPaused: Freeze and continue frame advancement
+- (AnimationPlayer) SeekTo: Jump to a specific frame index
+- (AnimationPlayer) GetCurrentFrame: Get the current frame index
+- (AnimationPlayer) Render: Render current frame to renderer, resizing the window when its size changes
+- (AnimationPlayer) scaledFrameSize: Size of a frame at the current scale
+- (AnimationPlayer) windowSizeChanged: Track the applied window size
+- (AnimationPlayer) SetScaleAnchor: Choose which point of the window stays put when it resizes (ScaleAnchor.go)
+- (AnimationPlayer) SetWindowMover: Route the window moves of anchored resizes through the caller (ScaleAnchor.go)
+- (AnimationPlayer) SetScale: Adjust character scale, eased when animated scaling is on
+- (AnimationPlayer) SetAnimatedScale: Choose between eased and instant scale changes
+- (AnimationPlayer) GetTargetScale: Get the scale being eased toward
+- (AnimationPlayer) stepScale: Move the scale along the easing curve
+- (AnimationPlayer) SetFlipHorizontal: Mirror the sprite horizontally
+- (AnimationPlayer) SetOpacity: Set sprite alpha in the range 0..1
+- (AnimationPlayer) SetTint: Modulate sprite colors (white keeps the original colors)
+- (AnimationPlayer) SetSpeed: Scale playback rate (2.0 = twice as fast)
+- (AnimationPlayer) IsOpaqueAt: Check if the current frame has a visible pixel under a point (AlphaMask.go)
 - (AnimationPlayer) GetScaledSize: Get current scaled dimensions
-- (AnimationPlayer) Cleanup: Destroy all textures and free resources
+- (AnimationPlayer) TextureStats: Count loaded textures and estimate their memory
+- (AnimationPlayer) Cleanup: Release cached textures and free resources
 */
 
 import (
 	"fmt"
+	"math"
 	"path/filepath"
 	"sort"
 
-	"github.com/jupiterrider/purego-sdl3/img"
 	"github.com/jupiterrider/purego-sdl3/sdl"
 )
 
 const (
 	DefaultFrameDelay = 83 // ~12fps
+	ScaleAnimationMs  = 200
+	MinScale          = 0.1
+	MinSpeed          = 0.1
+	MaxSpeed          = 10.0
 )
 
+type LoopMode int
+
+const (
+	LoopModeLoop LoopMode = iota
+	LoopModeOnce
+	LoopModePingPong
+)
+
+// frameSet holds one state's frames uploaded to one renderer. Sprite-sheet
+// frames share a single texture and carry a source rectangle per frame.
+type frameSet struct {
+	*decodedFrames
+	textures []*sdl.Texture
+}
+
 type AnimationPlayer struct {
+	renderer      *sdl.Renderer
+	states        map[string]*frameSet
+	stateDirs     map[string]string
+	state         string
 	textures      []*sdl.Texture
 	originalSizes []sdl.Point
+	frameDelays   []uint64
+	srcRects      []sdl.FRect
+	masks         []*AlphaMask
 	currentFrame  int
 	scale         float64
+	targetScale   float64
+	scaleFrom     float64
+	scaleStart    uint64 // ticks in ms when the current easing began
+	animatedScale bool
 	frameDelay    uint64
 	lastFrameTime uint64
 	framesPath    string
+	manifest      AnimationManifest
+	loopMode      LoopMode
+	direction     int
+	reverse       bool
+	paused        bool
+	finished      bool
+	flip          sdl.FlipMode
+	alpha         uint8
+	tint          sdl.Color
+	speed         float64
+	// windowSize is the size last passed to SDL_SetWindowSize, so Render
+	// only resizes when the scale or the frame dimensions change.
+	windowSize sdl.Point
+	anchor     ScaleAnchor
+	// anchorRest carries the sub-pixel part of anchor moves, so a long eased
+	// resize doesn't make the window drift.
+	anchorRest sdl.FPoint
+	moveWindow func(x, y int32)
 }
 
-func NewAnimationPlayer(framesPath string) *AnimationPlayer {
+func NewAnimationPlayer(framesPath string, scale float64) *AnimationPlayer {
 	return &AnimationPlayer{
+		states:        make(map[string]*frameSet),
+		stateDirs:     make(map[string]string),
 		textures:      make([]*sdl.Texture, 0),
 		originalSizes: make([]sdl.Point, 0),
 		currentFrame:  0,
-		scale:         0.51,
+		scale:         max(MinScale, scale),
+		targetScale:   max(MinScale, scale),
 		frameDelay:    DefaultFrameDelay,
+		direction:     1,
+		alpha:         255,
+		speed:         1.0,
+		tint:          sdl.Color{R: 255, G: 255, B: 255, A: 255},
 		lastFrameTime: 0,
 		framesPath:    framesPath,
+		anchor:        AnchorCenter,
 	}
 }
 
 func (ap *AnimationPlayer) LoadFrames(renderer *sdl.Renderer) error {
-	imageFiles, err := filepath.Glob(filepath.Join(ap.framesPath, "*.png"))
+	manifest, err := LoadManifest(ap.framesPath)
 	if err != nil {
-		return fmt.Errorf("error finding images: %w", err)
+		fmt.Printf("Warning: %v, using defaults\n", err)
 	}
+	ap.manifest = manifest
+	ap.frameDelay = manifest.FrameDelay()
 
-	if len(imageFiles) == 0 {
-		return fmt.Errorf("no PNG images found in %s", ap.framesPath)
+	stateDirs, err := FindStateDirs(ap.framesPath)
+	if err != nil {
+		return err
+	}
+
+	if len(stateDirs) == 0 {
+		return fmt.Errorf("no PNG/JPG images found in %s", ap.framesPath)
 	}
 
-	sort.Strings(imageFiles)
+	ap.renderer = renderer
 
-	for _, file := range imageFiles {
-		surface := img.Load(file)
-		if surface == nil {
-			fmt.Printf("Failed to load %s: %s\n", file, sdl.GetError())
+	total := 0
+	for name, dir := range stateDirs {
+		set, err := acquireFrameSet(renderer, dir)
+		if err != nil {
+			fmt.Printf("Failed to load state %q: %v\n", name, err)
 			continue
 		}
+		ap.states[name] = set
+		ap.stateDirs[name] = dir
+		total += len(set.textures)
+	}
+
+	if len(ap.states) == 0 {
+		return fmt.Errorf("failed to load any textures")
+	}
+
+	initial := DefaultState
+	if _, ok := ap.states[initial]; !ok {
+		initial = ap.States()[0]
+	}
+	ap.SetState(initial)
+
+	fmt.Printf("Total frames loaded: %d (%d states)\n", total, len(ap.states))
+	return nil
+}
+
+// decodeFrames reads a state directory as a sprite sheet, a lone GIF or
+// separate frame files, in that order.
+func decodeFrames(dir string) (*decodedFrames, error) {
+	sheet, err := LoadSpriteSheet(dir)
+	if err != nil {
+		return nil, err
+	}
+	if sheet != nil {
+		return decodeSpriteSheetFrames(dir, sheet)
+	}
+
+	if gifPath, ok := findLoneGif(dir); ok {
+		return decodeGifFrameSet(gifPath)
+	}
+
+	imageFiles, err := FindFrameFiles(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error finding images: %w", err)
+	}
 
-		width := int32(surface.W)
-		height := int32(surface.H)
-		ap.originalSizes = append(ap.originalSizes, sdl.Point{X: width, Y: height})
+	if len(imageFiles) == 0 {
+		return nil, fmt.Errorf("no PNG/JPG images found in %s", dir)
+	}
 
-		texture := sdl.CreateTextureFromSurface(renderer, surface)
-		sdl.DestroySurface(surface)
+	frames := &decodedFrames{}
+	var loadedFiles []string
+	for _, file := range imageFiles {
+		decoded, err := decodeImageFile(file)
+		if err != nil {
+			fmt.Printf("Failed to load %s: %v\n", file, err)
+			continue
+		}
 
-		if texture != nil {
-			sdl.SetTextureBlendMode(texture, sdl.BlendModeBlend)
-			ap.textures = append(ap.textures, texture)
-			fmt.Printf("Loaded: %s (%dx%d)\n", filepath.Base(file), width, height)
-		} else {
-			fmt.Printf("Failed to create texture for %s: %s\n", filepath.Base(file), sdl.GetError())
-			ap.originalSizes = ap.originalSizes[:len(ap.originalSizes)-1]
+		img := toNRGBA(decoded)
+		size := img.Bounds().Size()
+		if size.X == 0 || size.Y == 0 {
+			fmt.Printf("Skipping empty frame %s\n", filepath.Base(file))
+			continue
 		}
+
+		frames.images = append(frames.images, img)
+		frames.originalSizes = append(frames.originalSizes, sdl.Point{X: int32(size.X), Y: int32(size.Y)})
+		frames.masks = append(frames.masks, maskFromNRGBA(img))
+		loadedFiles = append(loadedFiles, file)
+		fmt.Printf("Loaded: %s (%dx%d)\n", filepath.Base(file), size.X, size.Y)
 	}
 
-	if len(ap.textures) == 0 {
-		return fmt.Errorf("failed to load any textures")
+	if len(frames.images) == 0 {
+		return nil, fmt.Errorf("failed to load any frames")
 	}
 
-	fmt.Printf("Total frames loaded: %d\n", len(ap.textures))
-	return nil
+	frames.frameDelays = resolveFrameDelays(dir, loadedFiles)
+
+	return frames, nil
+}
+
+func (ap *AnimationPlayer) SetState(name string) bool {
+	set, ok := ap.states[name]
+	if !ok {
+		return false
+	}
+
+	ap.state = name
+	ap.textures = set.textures
+	ap.originalSizes = set.originalSizes
+	ap.frameDelays = set.frameDelays
+	ap.srcRects = set.srcRects
+	ap.masks = set.masks
+	ap.currentFrame = 0
+	ap.lastFrameTime = sdl.GetTicks()
+	ap.resetDirection()
+	ap.finished = false
+	return true
+}
+
+func (ap *AnimationPlayer) resetDirection() {
+	ap.direction = 1
+	if ap.reverse {
+		ap.direction = -1
+	}
+}
+
+func (ap *AnimationPlayer) GetState() string {
+	return ap.state
+}
+
+func (ap *AnimationPlayer) States() []string {
+	names := make([]string, 0, len(ap.states))
+	for name := range ap.states {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (ap *AnimationPlayer) GetManifest() AnimationManifest {
+	return ap.manifest
 }
 
 func (ap *AnimationPlayer) Update() {
@@ -97,49 +276,262 @@ func (ap *AnimationPlayer) Update() {
 		return
 	}
 
+	// Scaling eases even while playback is paused or finished.
 	currentTime := sdl.GetTicks()
-	if currentTime-ap.lastFrameTime >= ap.frameDelay {
-		ap.currentFrame = (ap.currentFrame + 1) % len(ap.textures)
+	ap.stepScale(currentTime)
+
+	if ap.paused || ap.finished {
+		return
+	}
+
+	if currentTime-ap.lastFrameTime >= ap.currentDelay() {
+		ap.advanceFrame()
 		ap.lastFrameTime = currentTime
 	}
 }
 
+func (ap *AnimationPlayer) currentDelay() uint64 {
+	delay := ap.frameDelay
+	if ap.currentFrame < len(ap.frameDelays) && ap.frameDelays[ap.currentFrame] > 0 {
+		delay = ap.frameDelays[ap.currentFrame]
+	}
+	return uint64(float64(delay) / ap.speed)
+}
+
+func (ap *AnimationPlayer) advanceFrame() {
+	last := len(ap.textures) - 1
+
+	step := 1
+	if ap.reverse {
+		step = -1
+	}
+
+	switch ap.loopMode {
+	case LoopModeOnce:
+		end := last
+		if ap.reverse {
+			end = 0
+		}
+		if ap.currentFrame == end {
+			ap.finished = true
+			return
+		}
+		ap.currentFrame += step
+	case LoopModePingPong:
+		if last == 0 {
+			return
+		}
+		next := ap.currentFrame + ap.direction
+		if next < 0 || next > last {
+			ap.direction = -ap.direction
+			next = ap.currentFrame + ap.direction
+		}
+		ap.currentFrame = next
+	default:
+		ap.currentFrame = (ap.currentFrame + step + len(ap.textures)) % len(ap.textures)
+	}
+}
+
+func (ap *AnimationPlayer) SetLoopMode(mode LoopMode) {
+	ap.loopMode = mode
+	ap.resetDirection()
+	ap.finished = false
+}
+
+func (ap *AnimationPlayer) GetLoopMode() LoopMode {
+	return ap.loopMode
+}
+
+// SetReverse changes playback direction without moving the current frame,
+// so toggling mid-playback neither skips nor repeats a frame.
+func (ap *AnimationPlayer) SetReverse(reverse bool) {
+	if ap.reverse == reverse {
+		return
+	}
+	ap.reverse = reverse
+	ap.direction = -ap.direction
+	ap.finished = false
+}
+
+func (ap *AnimationPlayer) IsReverse() bool {
+	return ap.reverse
+}
+
+func (ap *AnimationPlayer) Pause() {
+	ap.paused = true
+}
+
+// Resume restarts the frame timer so the current frame gets its full delay.
+func (ap *AnimationPlayer) Resume() {
+	if !ap.paused {
+		return
+	}
+	ap.paused = false
+	ap.lastFrameTime = sdl.GetTicks()
+}
+
+func (ap *AnimationPlayer) IsPaused() bool {
+	return ap.paused
+}
+
+func (ap *AnimationPlayer) SeekTo(frame int) {
+	if len(ap.textures) == 0 {
+		return
+	}
+	ap.currentFrame = min(max(frame, 0), len(ap.textures)-1)
+	ap.lastFrameTime = sdl.GetTicks()
+	ap.finished = false
+}
+
+func (ap *AnimationPlayer) GetCurrentFrame() int {
+	return ap.currentFrame
+}
+
+func (ap *AnimationPlayer) IsFinished() bool {
+	return ap.finished
+}
+
 func (ap *AnimationPlayer) Render(renderer *sdl.Renderer, window *sdl.Window) {
 	if len(ap.textures) == 0 {
 		return
 	}
 
 	texture := ap.textures[ap.currentFrame]
-	orig := ap.originalSizes[ap.currentFrame]
-
-	scaledW := float32(float64(orig.X) * ap.scale)
-	scaledH := float32(float64(orig.Y) * ap.scale)
+	scaledW, scaledH := ap.scaledFrameSize(ap.currentFrame)
 
 	dst := sdl.FRect{X: 0, Y: 0, W: scaledW, H: scaledH}
 
-	sdl.SetWindowSize(window, int32(scaledW), int32(scaledH))
-	sdl.RenderTexture(renderer, texture, nil, &dst)
+	var src *sdl.FRect
+	if ap.currentFrame < len(ap.srcRects) {
+		src = &ap.srcRects[ap.currentFrame]
+	}
+
+	prevSize := ap.windowSize
+	if ap.windowSizeChanged(int32(scaledW), int32(scaledH)) {
+		// The first resize only replaces the placeholder size of a new window.
+		var x, y int32
+		if prevSize != (sdl.Point{}) && sdl.GetWindowPosition(window, &x, &y) {
+			if dx, dy := ap.anchorOffset(prevSize, ap.windowSize); dx != 0 || dy != 0 {
+				if ap.moveWindow != nil {
+					ap.moveWindow(x+dx, y+dy)
+				} else {
+					sdl.SetWindowPosition(window, x+dx, y+dy)
+				}
+			}
+		}
+		sdl.SetWindowSize(window, int32(scaledW), int32(scaledH))
+	}
+	sdl.SetTextureAlphaMod(texture, ap.alpha)
+	sdl.SetTextureColorMod(texture, ap.tint.R, ap.tint.G, ap.tint.B)
+	sdl.RenderTextureRotated(renderer, texture, src, &dst, 0, nil, ap.flip)
+}
+
+// scaledFrameSize is the size frame is drawn at with the current scale.
+func (ap *AnimationPlayer) scaledFrameSize(frame int) (float32, float32) {
+	orig := ap.originalSizes[frame]
+	return float32(float64(orig.X) * ap.scale), float32(float64(orig.Y) * ap.scale)
+}
+
+// windowSizeChanged records w x h as the applied window size and reports
+// whether it differs from the previous one.
+func (ap *AnimationPlayer) windowSizeChanged(w, h int32) bool {
+	size := sdl.Point{X: w, Y: h}
+	if size == ap.windowSize {
+		return false
+	}
+	ap.windowSize = size
+	return true
 }
 
 func (ap *AnimationPlayer) SetScale(scale float64) {
-	if scale < 0.1 {
-		scale = 0.1
+	ap.targetScale = max(MinScale, scale)
+	if !ap.animatedScale {
+		ap.scale = ap.targetScale
+		return
+	}
+	ap.scaleFrom = ap.scale
+	ap.scaleStart = sdl.GetTicks()
+}
+
+// SetAnimatedScale finishes any easing in progress when turned off.
+func (ap *AnimationPlayer) SetAnimatedScale(enabled bool) {
+	ap.animatedScale = enabled
+	if !enabled {
+		ap.scale = ap.targetScale
+	}
+}
+
+func (ap *AnimationPlayer) GetTargetScale() float64 {
+	return ap.targetScale
+}
+
+// stepScale eases out, so the size changes quickly at first and settles
+// gently on the target after ScaleAnimationMs.
+func (ap *AnimationPlayer) stepScale(now uint64) {
+	if ap.scale == ap.targetScale {
+		return
+	}
+
+	t := float64(now-ap.scaleStart) / ScaleAnimationMs
+	if t >= 1 {
+		ap.scale = ap.targetScale
+		return
 	}
-	ap.scale = scale
+	eased := 1 - math.Pow(1-t, 3)
+	ap.scale = ap.scaleFrom + (ap.targetScale-ap.scaleFrom)*eased
+}
+
+func (ap *AnimationPlayer) SetFlipHorizontal(flip bool) {
+	if flip {
+		ap.flip = sdl.FlipHorizontal
+	} else {
+		ap.flip = sdl.FlipNone
+	}
+}
+
+func (ap *AnimationPlayer) IsFlippedHorizontal() bool {
+	return ap.flip == sdl.FlipHorizontal
+}
+
+func (ap *AnimationPlayer) SetOpacity(alpha float64) {
+	alpha = min(max(alpha, 0), 1)
+	ap.alpha = uint8(math.Round(alpha * 255))
+}
+
+func (ap *AnimationPlayer) GetOpacity() float64 {
+	return float64(ap.alpha) / 255
+}
+
+func (ap *AnimationPlayer) SetTint(r, g, b uint8) {
+	ap.tint = sdl.Color{R: r, G: g, B: b, A: 255}
+}
+
+func (ap *AnimationPlayer) GetTint() (uint8, uint8, uint8) {
+	return ap.tint.R, ap.tint.G, ap.tint.B
+}
+
+func (ap *AnimationPlayer) SetSpeed(multiplier float64) {
+	ap.speed = min(max(multiplier, MinSpeed), MaxSpeed)
+}
+
+func (ap *AnimationPlayer) GetSpeed() float64 {
+	return ap.speed
 }
 
 func (ap *AnimationPlayer) GetScale() float64 {
 	return ap.scale
 }
 
+// ScaleUp and ScaleDown step from the target scale, so repeated presses
+// during an easing keep adding up.
 func (ap *AnimationPlayer) ScaleUp() {
-	ap.scale *= 1.1
-	fmt.Printf("Scale: %.2f\n", ap.scale)
+	ap.SetScale(ap.targetScale * 1.1)
+	fmt.Printf("Scale: %.2f\n", ap.targetScale)
 }
 
 func (ap *AnimationPlayer) ScaleDown() {
-	ap.scale = max(0.1, ap.scale/1.1)
-	fmt.Printf("Scale: %.2f\n", ap.scale)
+	ap.SetScale(ap.targetScale / 1.1)
+	fmt.Printf("Scale: %.2f\n", ap.targetScale)
 }
 
 func (ap *AnimationPlayer) GetScaledSize() (int32, int32) {
@@ -158,11 +550,33 @@ func (ap *AnimationPlayer) FrameCount() int {
 	return len(ap.textures)
 }
 
+// TextureStats covers every loaded state. The memory estimate assumes 4 bytes
+// per pixel of each frame's original size.
+func (ap *AnimationPlayer) TextureStats() (textures int, bytes int64) {
+	seen := make(map[*sdl.Texture]bool)
+	for _, set := range ap.states {
+		for i, t := range set.textures {
+			if !seen[t] {
+				seen[t] = true
+				textures++
+			}
+			size := set.originalSizes[i]
+			bytes += int64(size.X) * int64(size.Y) * 4
+		}
+	}
+	return textures, bytes
+}
+
 func (ap *AnimationPlayer) Cleanup() {
-	for _, t := range ap.textures {
-		sdl.DestroyTexture(t)
+	for _, dir := range ap.stateDirs {
+		releaseFrameSet(ap.renderer, dir)
 	}
+	ap.states = make(map[string]*frameSet)
+	ap.stateDirs = make(map[string]string)
 	ap.textures = nil
 	ap.originalSizes = nil
+	ap.frameDelays = nil
+	ap.srcRects = nil
+	ap.masks = nil
 	fmt.Println("Animation resources cleaned up")
 }
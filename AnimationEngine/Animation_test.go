@@ -0,0 +1,60 @@
+package AnimationEngine
+
+import (
+	"testing"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// resizesOverLoops counts how often Render would resize the window while
+// the player shows every frame loops times.
+func resizesOverLoops(ap *AnimationPlayer, loops int) int {
+	resizes := 0
+	for i := 0; i < loops*len(ap.originalSizes); i++ {
+		w, h := ap.scaledFrameSize(i % len(ap.originalSizes))
+		if ap.windowSizeChanged(int32(w), int32(h)) {
+			resizes++
+		}
+	}
+	return resizes
+}
+
+func TestUniformFramesResizeOnce(t *testing.T) {
+	ap := &AnimationPlayer{
+		scale:         0.75,
+		originalSizes: []sdl.Point{{X: 200, Y: 180}, {X: 200, Y: 180}, {X: 200, Y: 180}},
+	}
+	if got := resizesOverLoops(ap, 5); got != 1 {
+		t.Errorf("resized %d times, want 1", got)
+	}
+
+	// A later frame at the same scale is already the right size.
+	if got := resizesOverLoops(ap, 1); got != 0 {
+		t.Errorf("resized %d times after the first size was applied, want 0", got)
+	}
+}
+
+func TestMixedFramesResizeOnChange(t *testing.T) {
+	ap := &AnimationPlayer{
+		scale:         1,
+		originalSizes: []sdl.Point{{X: 100, Y: 100}, {X: 100, Y: 100}, {X: 120, Y: 100}},
+	}
+	// The first pass resizes for frame 0 and frame 2, then every loop
+	// resizes going back to frame 0 and again at frame 2.
+	if got := resizesOverLoops(ap, 3); got != 6 {
+		t.Errorf("resized %d times, want 6", got)
+	}
+}
+
+func TestScaleChangeResizes(t *testing.T) {
+	ap := &AnimationPlayer{
+		scale:         1,
+		originalSizes: []sdl.Point{{X: 100, Y: 100}},
+	}
+	resizesOverLoops(ap, 1)
+
+	ap.scale = 1.5
+	if got := resizesOverLoops(ap, 3); got != 1 {
+		t.Errorf("resized %d times after a scale change, want 1", got)
+	}
+}
@@ -5,8 +5,21 @@ CharacterLoader.go - Utilities for discovering and loading characters
 
 Functions:
 - ScanCharacters: Scan Frames directory and return list of available characters
+- LoadCharacterInfo: Read a single character folder without scanning the others
+- SortCharacters: Order a character list by name, frame count or folder modification time
 - GetCharacterFramesPath: Get full path to character's frames directory
 - GetPreviewImage: Get path to first frame as preview thumbnail
+- ValidateCharacter: Check that a character's first frame can be decoded
+- FindStateDirs: Map animation state names to their frame directories
+- GetCharacterStates: List a character's animation state names
+- FindFrameFiles: List PNG/JPG frame files of a directory in natural order
+- FindPreviewFrames: List the frame files of a character's first state in natural order
+- IsFrameFile: Check if a file name has a supported still-frame extension
+- FrameMIMEType: MIME type of a still-frame file name
+- hasFrames: Check if a directory holds PNG/JPG frames or a lone GIF
+- checkFrameDecodes: Decode a frame file once per size and modification time
+- scanFrameDir: Find the preview image and frame count of one frame directory
+- sortedStates: Sort state names with the default state first
 */
 
 import (
@@ -14,13 +27,52 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultState is the state name for frames placed directly in the character folder.
+const DefaultState = "default"
+
+// Sort orders accepted by SortCharacters.
+const (
+	SortByName       = "name"       // A to Z, the default
+	SortByFrameCount = "frameCount" // most frames first
+	SortByRecent     = "recent"     // most recently modified folder first
+)
+
+// decodeCheck is the outcome of decoding one version of a frame file.
+type decodeCheck struct {
+	size    int64
+	modTime time.Time
+	err     error
+}
+
+var (
+	decodeChecksMu sync.Mutex
+	decodeChecks   = make(map[string]decodeCheck)
 )
 
 type CharacterInfo struct {
-	Name        string `json:"name"`
-	Path        string `json:"path"`
-	PreviewPath string `json:"previewPath"`
-	FrameCount  int    `json:"frameCount"`
+	Name        string  `json:"name"`
+	Path        string  `json:"path"`
+	PreviewPath string  `json:"previewPath"`
+	FrameCount  int     `json:"frameCount"` // summed over all states
+	FPS         float64 `json:"fps"`
+	// States lists the animation states when the character has state
+	// subfolders; flat characters leave it empty.
+	States []string `json:"states,omitempty"`
+	// DisplayName comes from character.json and falls back to Name.
+	DisplayName string `json:"displayName"`
+	Author      string `json:"author,omitempty"`
+	Description string `json:"description,omitempty"`
+	// IsFavorite is filled in by the app from the config; ScanCharacters
+	// leaves it false.
+	IsFavorite bool `json:"isFavorite"`
+	// Valid is false when the first frame exists but cannot be decoded, so
+	// spawning the character would only produce an empty window.
+	Valid bool `json:"valid"`
 }
 
 func ScanCharacters(basePath string) ([]CharacterInfo, error) {
@@ -36,27 +88,147 @@ func ScanCharacters(basePath string) ([]CharacterInfo, error) {
 			continue
 		}
 
-		charPath := filepath.Join(basePath, entry.Name())
-		frames, err := filepath.Glob(filepath.Join(charPath, "*.png"))
-		if err != nil {
-			continue
+		if info, ok := LoadCharacterInfo(basePath, entry.Name()); ok {
+			characters = append(characters, info)
 		}
+	}
 
-		if len(frames) == 0 {
-			continue
+	return characters, nil
+}
+
+// LoadCharacterInfo reads one character folder the same way ScanCharacters
+// does. ok is false when the folder is missing or has no frames.
+func LoadCharacterInfo(basePath, characterName string) (CharacterInfo, bool) {
+	charPath := filepath.Join(basePath, characterName)
+	if info, err := os.Stat(charPath); err != nil || !info.IsDir() {
+		return CharacterInfo{}, false
+	}
+
+	previewPath, frameCount := scanFrameDir(charPath)
+
+	// Characters organized as Name/state/frame.png have no frames of
+	// their own; count every state subfolder instead.
+	var states []string
+	if stateDirs, err := FindStateDirs(charPath); err == nil && len(stateDirs) > 0 {
+		if _, flat := stateDirs[DefaultState]; !flat || len(stateDirs) > 1 {
+			states = sortedStates(stateDirs)
+			for _, state := range states {
+				if state == DefaultState {
+					continue
+				}
+				statePreview, count := scanFrameDir(stateDirs[state])
+				if previewPath == "" {
+					previewPath = statePreview
+				}
+				frameCount += count
+			}
 		}
+	}
+
+	if frameCount == 0 {
+		return CharacterInfo{}, false
+	}
+
+	manifest, err := LoadManifest(charPath)
+	if err != nil {
+		fmt.Printf("Warning: %s: %v\n", characterName, err)
+	}
+
+	metadata, err := LoadCharacterMetadata(charPath)
+	if err != nil {
+		fmt.Printf("Warning: %s: %v\n", characterName, err)
+	}
+	if metadata.DisplayName == "" {
+		metadata.DisplayName = characterName
+	}
+
+	valid := true
+	if err := checkFrameDecodes(previewPath); err != nil {
+		fmt.Printf("Warning: %s cannot be loaded: %v\n", characterName, err)
+		valid = false
+	}
 
-		sort.Strings(frames)
+	return CharacterInfo{
+		Name:        characterName,
+		Path:        charPath,
+		PreviewPath: previewPath,
+		FrameCount:  frameCount,
+		FPS:         manifest.ResolvedFPS(),
+		States:      states,
+		DisplayName: metadata.DisplayName,
+		Author:      metadata.Author,
+		Description: metadata.Description,
+		Valid:       valid,
+	}, true
+}
+
+// SortCharacters sorts in place and keeps the relative order of equal
+// entries. Unknown orders fall back to SortByName; name ties are broken by
+// folder name so the result doesn't depend on filesystem order.
+func SortCharacters(characters []CharacterInfo, sortBy string) {
+	byName := func(a, b CharacterInfo) bool {
+		nameA, nameB := strings.ToLower(a.DisplayName), strings.ToLower(b.DisplayName)
+		if nameA != nameB {
+			return nameA < nameB
+		}
+		return a.Name < b.Name
+	}
 
-		characters = append(characters, CharacterInfo{
-			Name:        entry.Name(),
-			Path:        charPath,
-			PreviewPath: frames[0],
-			FrameCount:  len(frames),
+	switch sortBy {
+	case SortByFrameCount:
+		sort.SliceStable(characters, func(i, j int) bool {
+			if characters[i].FrameCount != characters[j].FrameCount {
+				return characters[i].FrameCount > characters[j].FrameCount
+			}
+			return byName(characters[i], characters[j])
+		})
+	case SortByRecent:
+		modTimes := make(map[string]time.Time, len(characters))
+		for _, char := range characters {
+			if info, err := os.Stat(char.Path); err == nil {
+				modTimes[char.Path] = info.ModTime()
+			}
+		}
+		sort.SliceStable(characters, func(i, j int) bool {
+			timeA, timeB := modTimes[characters[i].Path], modTimes[characters[j].Path]
+			if !timeA.Equal(timeB) {
+				return timeA.After(timeB)
+			}
+			return byName(characters[i], characters[j])
+		})
+	default:
+		sort.SliceStable(characters, func(i, j int) bool {
+			return byName(characters[i], characters[j])
 		})
 	}
+}
 
-	return characters, nil
+// scanFrameDir checks a sprite sheet first, then PNG/JPG frames, then a lone GIF.
+func scanFrameDir(dir string) (string, int) {
+	if sheet, err := LoadSpriteSheet(dir); err == nil && sheet != nil {
+		return filepath.Join(dir, filepath.Base(sheet.Image)), sheet.Count
+	}
+	if frames, err := FindFrameFiles(dir); err == nil && len(frames) > 0 {
+		return frames[0], len(frames)
+	}
+	if gifPath, ok := findLoneGif(dir); ok {
+		return gifPath, countGifFrames(gifPath)
+	}
+	return "", 0
+}
+
+func sortedStates(stateDirs map[string]string) []string {
+	states := make([]string, 0, len(stateDirs))
+	for name := range stateDirs {
+		states = append(states, name)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		if (states[i] == DefaultState) != (states[j] == DefaultState) {
+			return states[i] == DefaultState
+		}
+		return states[i] < states[j]
+	})
+	return states
 }
 
 func GetCharacterFramesPath(basePath, characterName string) string {
@@ -65,15 +237,157 @@ func GetCharacterFramesPath(basePath, characterName string) string {
 
 func GetPreviewImage(basePath, characterName string) (string, error) {
 	charPath := filepath.Join(basePath, characterName)
-	frames, err := filepath.Glob(filepath.Join(charPath, "*.png"))
+	frames, err := FindFrameFiles(charPath)
 	if err != nil {
 		return "", err
 	}
 
 	if len(frames) == 0 {
+		if gifPath, ok := findLoneGif(charPath); ok {
+			return gifPath, nil
+		}
+		if stateDirs, err := FindStateDirs(charPath); err == nil {
+			for _, state := range sortedStates(stateDirs) {
+				if previewPath, _ := scanFrameDir(stateDirs[state]); previewPath != "" {
+					return previewPath, nil
+				}
+			}
+		}
 		return "", fmt.Errorf("no frames found for character %s", characterName)
 	}
 
-	sort.Strings(frames)
 	return frames[0], nil
 }
+
+// ValidateCharacter decodes the preview frame with the image package only;
+// nothing is uploaded to a renderer. Later frames are not checked.
+func ValidateCharacter(basePath, characterName string) error {
+	previewPath, err := GetPreviewImage(basePath, characterName)
+	if err != nil {
+		return err
+	}
+	if err := checkFrameDecodes(previewPath); err != nil {
+		return fmt.Errorf("character %s cannot be loaded: %w", characterName, err)
+	}
+	return nil
+}
+
+func FindStateDirs(charPath string) (map[string]string, error) {
+	entries, err := os.ReadDir(charPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read character directory: %w", err)
+	}
+
+	states := make(map[string]string)
+
+	if hasFrames(charPath) {
+		states[DefaultState] = charPath
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		statePath := filepath.Join(charPath, entry.Name())
+		if hasFrames(statePath) {
+			if _, exists := states[entry.Name()]; !exists {
+				states[entry.Name()] = statePath
+			}
+		}
+	}
+
+	return states, nil
+}
+
+func GetCharacterStates(basePath, characterName string) ([]string, error) {
+	stateDirs, err := FindStateDirs(filepath.Join(basePath, characterName))
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]string, 0, len(stateDirs))
+	for name := range stateDirs {
+		states = append(states, name)
+	}
+	sort.Strings(states)
+	return states, nil
+}
+
+func FindFrameFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && IsFrameFile(entry.Name()) {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	SortNatural(files)
+	return files, nil
+}
+
+// FindPreviewFrames uses the frames in the character folder itself, or the
+// first state subfolder with PNG/JPG frames when it has none.
+func FindPreviewFrames(basePath, characterName string) ([]string, error) {
+	stateDirs, err := FindStateDirs(filepath.Join(basePath, characterName))
+	if err != nil {
+		return nil, err
+	}
+	for _, state := range sortedStates(stateDirs) {
+		if frames, err := FindFrameFiles(stateDirs[state]); err == nil && len(frames) > 0 {
+			return frames, nil
+		}
+	}
+	return nil, nil
+}
+
+func IsFrameFile(name string) bool {
+	return FrameMIMEType(name) != ""
+}
+
+// FrameMIMEType returns "" for names that are not PNG/JPG frames.
+func FrameMIMEType(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".png":
+		return "image/png"
+	case ".jpg", ".jpeg":
+		return "image/jpeg"
+	}
+	return ""
+}
+
+func hasFrames(dir string) bool {
+	if frames, _ := FindFrameFiles(dir); len(frames) > 0 {
+		return true
+	}
+	_, ok := findLoneGif(dir)
+	return ok
+}
+
+// checkFrameDecodes fully decodes path the first time it is seen and again
+// only when its size or modification time changes, so rescanning the
+// character list doesn't decode every preview frame each time.
+func checkFrameDecodes(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	decodeChecksMu.Lock()
+	check, ok := decodeChecks[path]
+	decodeChecksMu.Unlock()
+	if ok && check.size == info.Size() && check.modTime.Equal(info.ModTime()) {
+		return check.err
+	}
+
+	_, err = decodeImageFile(path)
+	decodeChecksMu.Lock()
+	decodeChecks[path] = decodeCheck{size: info.Size(), modTime: info.ModTime(), err: err}
+	decodeChecksMu.Unlock()
+	return err
+}
@@ -0,0 +1,163 @@
+package AnimationEngine
+
+import (
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFrameMIMEType(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"1.png", "image/png"},
+		{"a.jpeg", "image/jpeg"},
+		{"a.jpg", "image/jpeg"},
+		{"FRAME_01.PNG", "image/png"},
+		{"Walk.JPG", "image/jpeg"},
+		{"anim.gif", ""},
+		{"frames.json", ""},
+		{"png", ""},
+	}
+	for _, tt := range tests {
+		if got := FrameMIMEType(tt.name); got != tt.want {
+			t.Errorf("FrameMIMEType(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+		if got := IsFrameFile(tt.name); got != (tt.want != "") {
+			t.Errorf("IsFrameFile(%q) = %v", tt.name, got)
+		}
+	}
+}
+
+// writeFiles creates empty files at the given paths below dir.
+func writeFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFindPreviewFrames(t *testing.T) {
+	base := t.TempDir()
+	writeFiles(t, base,
+		"Flat/2.png", "Flat/10.png", "Flat/1.png", "Flat/idle/1.png",
+		"States/walk/1.png", "States/idle/2.png", "States/idle/1.png",
+	)
+
+	tests := []struct {
+		character string
+		want      []string
+	}{
+		{"Flat", []string{"Flat/1.png", "Flat/2.png", "Flat/10.png"}},
+		{"States", []string{"States/idle/1.png", "States/idle/2.png"}},
+	}
+	for _, tt := range tests {
+		frames, err := FindPreviewFrames(base, tt.character)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(frames) != len(tt.want) {
+			t.Fatalf("FindPreviewFrames(%s) = %v, want %v", tt.character, frames, tt.want)
+		}
+		for i, want := range tt.want {
+			if frames[i] != filepath.Join(base, filepath.FromSlash(want)) {
+				t.Errorf("FindPreviewFrames(%s)[%d] = %s, want %s", tt.character, i, frames[i], want)
+			}
+		}
+	}
+}
+
+// writeImage writes a small opaque frame, encoded by the file's extension.
+func writeImage(t *testing.T, path string) {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for i := range img.Pix {
+		img.Pix[i] = 255
+	}
+	img.SetNRGBA(0, 0, color.NRGBA{R: 10, G: 20, B: 30, A: 255})
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if FrameMIMEType(path) == "image/jpeg" {
+		err = jpeg.Encode(file, img, nil)
+	} else {
+		err = png.Encode(file, img)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMixedJPGAndPNGFrames(t *testing.T) {
+	base := t.TempDir()
+	dir := filepath.Join(base, "Mixed")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	names := []string{"1.jpg", "2.PNG", "3.jpeg", "10.png", "11.JPG"}
+	for _, name := range names {
+		writeImage(t, filepath.Join(dir, name))
+	}
+	writeFiles(t, dir, "notes.txt", "frames.json")
+
+	frames, err := FindFrameFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != len(names) {
+		t.Fatalf("FindFrameFiles found %d frames, want %d", len(frames), len(names))
+	}
+	for i, name := range names {
+		if frames[i] != filepath.Join(dir, name) {
+			t.Errorf("frame %d is %s, want %s", i, filepath.Base(frames[i]), name)
+		}
+	}
+
+	characters, err := ScanCharacters(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(characters) != 1 || characters[0].FrameCount != len(names) || !characters[0].Valid {
+		t.Fatalf("ScanCharacters = %+v, want one valid character with %d frames", characters, len(names))
+	}
+
+	decoded, err := decodeFrames(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.images) != len(names) {
+		t.Errorf("loaded %d frames, want the %d that were counted", len(decoded.images), len(names))
+	}
+}
+
+func TestCheckFrameDecodesNoticesChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "1.png")
+	if err := os.WriteFile(path, []byte("not a png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if checkFrameDecodes(path) == nil {
+		t.Fatal("broken frame reported as decodable")
+	}
+	if checkFrameDecodes(path) == nil {
+		t.Fatal("cached check forgot the frame is broken")
+	}
+
+	writeImage(t, path)
+	if err := checkFrameDecodes(path); err != nil {
+		t.Fatalf("fixed frame still reported broken: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package AnimationEngine
+
+/*
+CharacterMetadata.go - Optional character.json describing a character folder
+
+Functions:
+- LoadCharacterMetadata: Read character.json from a character directory
+*/
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const MetadataFileName = "character.json"
+
+type CharacterMetadata struct {
+	DisplayName string `json:"displayName,omitempty"`
+	Author      string `json:"author,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// LoadCharacterMetadata returns empty metadata without an error when the
+// file doesn't exist.
+func LoadCharacterMetadata(charPath string) (CharacterMetadata, error) {
+	var metadata CharacterMetadata
+
+	data, err := os.ReadFile(filepath.Join(charPath, MetadataFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return metadata, nil
+		}
+		return metadata, fmt.Errorf("failed to read %s: %w", MetadataFileName, err)
+	}
+
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return CharacterMetadata{}, fmt.Errorf("failed to parse %s: %w", MetadataFileName, err)
+	}
+
+	metadata.DisplayName = strings.TrimSpace(metadata.DisplayName)
+	return metadata, nil
+}
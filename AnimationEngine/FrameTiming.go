@@ -0,0 +1,71 @@
+package AnimationEngine
+
+/*
+FrameTiming.go - Per-frame delays from frames.json
+
+frames.json maps frame file names to a delay in milliseconds, e.g.
+{"open.png": 2000, "blink1.png": 60}. Frames without an entry use the
+character's global frame delay.
+
+Functions:
+- loadFrameTimings: Read frames.json from a directory if present
+- resolveFrameDelays: Build the delay slice for loaded frames
+*/
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const FrameTimingFileName = "frames.json"
+
+func loadFrameTimings(dir string) (map[string]uint64, error) {
+	data, err := os.ReadFile(filepath.Join(dir, FrameTimingFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var timings map[string]uint64
+	if err := json.Unmarshal(data, &timings); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", FrameTimingFileName, err)
+	}
+	return timings, nil
+}
+
+// resolveFrameDelays returns a delay per loaded frame, 0 meaning "use the
+// global delay", or nil when frames.json is absent.
+func resolveFrameDelays(dir string, loadedFiles []string) []uint64 {
+	timings, err := loadFrameTimings(dir)
+	if err != nil {
+		fmt.Printf("Warning: %v, using global frame delay\n", err)
+		return nil
+	}
+	if timings == nil {
+		return nil
+	}
+
+	if len(timings) != len(loadedFiles) {
+		fmt.Printf("Warning: %s has %d entries but %d frames were loaded from %s\n",
+			FrameTimingFileName, len(timings), len(loadedFiles), dir)
+	}
+
+	delays := make([]uint64, len(loadedFiles))
+	matched := 0
+	for i, file := range loadedFiles {
+		if delay, ok := timings[filepath.Base(file)]; ok {
+			delays[i] = delay
+			matched++
+		}
+	}
+
+	if matched < len(timings) {
+		fmt.Printf("Warning: %d %s entries do not match any loaded frame\n", len(timings)-matched, FrameTimingFileName)
+	}
+
+	return delays
+}
@@ -0,0 +1,198 @@
+package AnimationEngine
+
+/*
+GifExport.go - Export a character's animation as a looping GIF
+
+Frames are decoded with the Go image packages, so no SDL window or renderer
+is needed. The default state is exported; sprite sheets are sliced into
+cells and lone GIFs are re-encoded frame by frame.
+
+GIF allows 256 colors with on/off transparency, so frames are dithered to a
+255-color palette and pixels below half alpha use the remaining transparent
+index.
+
+Functions:
+- ExportGif: Write a character's default state to a looping GIF file
+- decodeFrameImages: Decode every frame of a state directory into images
+- decodeImageFile: Decode a single PNG/JPG file
+- quantizeFrame: Convert an image to a paletted frame with a transparent index
+*/
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+)
+
+const (
+	gifAlphaCutoff   = 0x80
+	transparentIndex = 255
+)
+
+// exportPalette is Plan 9's palette with its last entry made transparent
+var exportPalette = append(slices.Clone(color.Palette(palette.Plan9[:transparentIndex])), color.Transparent)
+
+// ExportGif uses the character's configured FPS when fps is not positive.
+func ExportGif(charPath, outputPath string, fps int) error {
+	stateDirs, err := FindStateDirs(charPath)
+	if err != nil {
+		return err
+	}
+
+	dir, ok := stateDirs[DefaultState]
+	if !ok {
+		names := make([]string, 0, len(stateDirs))
+		for name := range stateDirs {
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("no frames found in %s", charPath)
+		}
+		sort.Strings(names)
+		dir = stateDirs[names[0]]
+	}
+
+	frames, err := decodeFrameImages(dir)
+	if err != nil {
+		return err
+	}
+
+	if fps <= 0 {
+		manifest, _ := LoadManifest(charPath)
+		fps = int(manifest.ResolvedFPS() + 0.5)
+	}
+	delay := max(1, 100/max(fps, 1)) // centiseconds
+
+	var bounds image.Rectangle
+	for _, frame := range frames {
+		bounds = bounds.Union(frame.Bounds().Sub(frame.Bounds().Min))
+	}
+
+	anim := &gif.GIF{LoopCount: 0}
+	for _, frame := range frames {
+		anim.Image = append(anim.Image, quantizeFrame(frame, bounds))
+		anim.Delay = append(anim.Delay, delay)
+		anim.Disposal = append(anim.Disposal, gif.DisposalBackground)
+	}
+	anim.Config = image.Config{ColorModel: exportPalette, Width: bounds.Dx(), Height: bounds.Dy()}
+	anim.BackgroundIndex = transparentIndex
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", outputPath, err)
+	}
+
+	if err := gif.EncodeAll(file, anim); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to encode GIF: %w", err)
+	}
+	return file.Close()
+}
+
+func decodeFrameImages(dir string) ([]image.Image, error) {
+	sheet, err := LoadSpriteSheet(dir)
+	if err != nil {
+		return nil, err
+	}
+	if sheet != nil {
+		img, err := decodeImageFile(filepath.Join(dir, filepath.Base(sheet.Image)))
+		if err != nil {
+			return nil, err
+		}
+		sub, ok := img.(interface {
+			SubImage(image.Rectangle) image.Image
+		})
+		if !ok {
+			return nil, fmt.Errorf("sprite sheet %s cannot be sliced", sheet.Image)
+		}
+
+		var frames []image.Image
+		for i := 0; i < sheet.Count; i++ {
+			r := sheet.cellRect(i)
+			cell := image.Rect(int(r.X), int(r.Y), int(r.X+r.W), int(r.Y+r.H)).Add(img.Bounds().Min)
+			if !cell.In(img.Bounds()) {
+				break
+			}
+			frames = append(frames, sub.SubImage(cell))
+		}
+		return frames, nil
+	}
+
+	if gifPath, ok := findLoneGif(dir); ok {
+		decoded, _, err := decodeGifFrames(gifPath)
+		if err != nil {
+			return nil, err
+		}
+		frames := make([]image.Image, len(decoded))
+		for i, frame := range decoded {
+			frames[i] = frame
+		}
+		return frames, nil
+	}
+
+	files, err := FindFrameFiles(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error finding images: %w", err)
+	}
+
+	var frames []image.Image
+	for _, file := range files {
+		img, err := decodeImageFile(file)
+		if err != nil {
+			fmt.Printf("Skipping %s: %v\n", filepath.Base(file), err)
+			continue
+		}
+		frames = append(frames, img)
+	}
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("no PNG/JPG images found in %s", dir)
+	}
+	return frames, nil
+}
+
+func decodeImageFile(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", filepath.Base(path), err)
+	}
+	return img, nil
+}
+
+func quantizeFrame(img image.Image, bounds image.Rectangle) *image.Paletted {
+	src := img.Bounds()
+	frame := image.NewPaletted(bounds, exportPalette)
+	draw.FloydSteinberg.Draw(frame, src.Sub(src.Min), img, src.Min)
+
+	// Dithering may leave stray transparent pixels inside the sprite and
+	// colored ones in faded edges; decide transparency by alpha alone.
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			p := image.Point{X: x, Y: y}
+			var a uint32
+			if p.Add(src.Min).In(src) {
+				_, _, _, a = img.At(x+src.Min.X, y+src.Min.Y).RGBA()
+			}
+			if a < gifAlphaCutoff<<8 {
+				frame.SetColorIndex(x, y, transparentIndex)
+			} else if frame.ColorIndexAt(x, y) == transparentIndex {
+				frame.SetColorIndex(x, y, uint8(exportPalette[:transparentIndex].Index(img.At(x+src.Min.X, y+src.Min.Y))))
+			}
+		}
+	}
+	return frame
+}
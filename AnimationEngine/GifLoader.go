@@ -0,0 +1,156 @@
+package AnimationEngine
+
+/*
+GifLoader.go - Animated GIF characters
+
+A character (or state) folder holding a single .gif and no PNG/JPG frames is
+played from the GIF, using the GIF's own per-frame delays.
+
+Functions:
+- findLoneGif: Return the only .gif in a directory when it has no PNG/JPG frames
+- decodeGifFrames: Decode and composite every GIF frame into RGBA images
+- countGifFrames: Count the frames of a GIF file
+- decodeGifFrameSet: Decode a GIF into frames ready for upload
+*/
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// gifMinDelay matches browsers, which treat 0-1 centisecond delays as 100ms.
+const gifMinDelay = 100
+
+func findLoneGif(dir string) (string, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", false
+	}
+
+	var gifPath string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if IsFrameFile(entry.Name()) {
+			return "", false
+		}
+		if strings.EqualFold(filepath.Ext(entry.Name()), ".gif") {
+			if gifPath != "" {
+				return "", false
+			}
+			gifPath = filepath.Join(dir, entry.Name())
+		}
+	}
+
+	return gifPath, gifPath != ""
+}
+
+func decodeGifFrames(path string) ([]*image.RGBA, []uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	g, err := gif.DecodeAll(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode %s: %w", filepath.Base(path), err)
+	}
+
+	if len(g.Image) == 0 {
+		return nil, nil, fmt.Errorf("no frames in %s", filepath.Base(path))
+	}
+
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() {
+		bounds = g.Image[0].Bounds()
+	}
+
+	canvas := image.NewRGBA(bounds)
+	frames := make([]*image.RGBA, 0, len(g.Image))
+	delays := make([]uint64, 0, len(g.Image))
+
+	for i, frame := range g.Image {
+		var previous *image.RGBA
+		disposal := byte(0)
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		if disposal == gif.DisposalPrevious {
+			previous = image.NewRGBA(bounds)
+			copy(previous.Pix, canvas.Pix)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+
+		snapshot := image.NewRGBA(bounds)
+		copy(snapshot.Pix, canvas.Pix)
+		frames = append(frames, snapshot)
+
+		delay := uint64(gifMinDelay)
+		if i < len(g.Delay) && g.Delay[i] > 1 {
+			delay = uint64(g.Delay[i]) * 10
+		}
+		delays = append(delays, delay)
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			copy(canvas.Pix, previous.Pix)
+		}
+	}
+
+	return frames, delays, nil
+}
+
+func countGifFrames(path string) int {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	g, err := gif.DecodeAll(file)
+	if err != nil {
+		return 0
+	}
+	return len(g.Image)
+}
+
+func decodeGifFrameSet(path string) (*decodedFrames, error) {
+	composited, delays, err := decodeGifFrames(path)
+	if err != nil {
+		return nil, err
+	}
+
+	frames := &decodedFrames{}
+	for i, frame := range composited {
+		img := toNRGBA(frame)
+		size := img.Bounds().Size()
+		if size.X == 0 || size.Y == 0 {
+			fmt.Printf("Skipping empty frame %d of %s\n", i, filepath.Base(path))
+			continue
+		}
+
+		frames.images = append(frames.images, img)
+		frames.originalSizes = append(frames.originalSizes, sdl.Point{X: int32(size.X), Y: int32(size.Y)})
+		frames.frameDelays = append(frames.frameDelays, delays[i])
+		frames.masks = append(frames.masks, maskFromNRGBA(img))
+	}
+
+	if len(frames.images) == 0 {
+		return nil, fmt.Errorf("failed to load any frames")
+	}
+
+	fmt.Printf("Loaded: %s (%d frames)\n", filepath.Base(path), len(frames.images))
+	return frames, nil
+}
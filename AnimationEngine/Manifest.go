@@ -0,0 +1,85 @@
+package AnimationEngine
+
+/*
+Manifest.go - Optional per-character animation.json settings
+
+Functions:
+- LoadManifest: Read animation.json from a character's frames directory
+- (AnimationManifest) ResolvedFPS: Get the manifest fps or the default
+- (AnimationManifest) FrameDelay: Get the per-frame delay in milliseconds
+- (DragRegion) Contains: Check if a normalized point lies inside the region
+*/
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	ManifestFileName = "animation.json"
+	DefaultFPS       = 12
+)
+
+// DragRegion is a rectangle in normalized window coordinates (0..1).
+type DragRegion struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	W float64 `json:"w"`
+	H float64 `json:"h"`
+}
+
+type AnimationManifest struct {
+	FPS        float64         `json:"fps,omitempty"`
+	DragRegion *DragRegion     `json:"dragRegion,omitempty"`
+	Schedule   []ScheduleEntry `json:"schedule,omitempty"`
+}
+
+func LoadManifest(framesPath string) (AnimationManifest, error) {
+	var manifest AnimationManifest
+
+	data, err := os.ReadFile(filepath.Join(framesPath, ManifestFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return manifest, nil
+		}
+		return manifest, fmt.Errorf("failed to read %s: %w", ManifestFileName, err)
+	}
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return AnimationManifest{}, fmt.Errorf("failed to parse %s: %w", ManifestFileName, err)
+	}
+
+	if r := manifest.DragRegion; r != nil && (r.W <= 0 || r.H <= 0) {
+		fmt.Printf("Ignoring empty dragRegion in %s\n", filepath.Join(framesPath, ManifestFileName))
+		manifest.DragRegion = nil
+	}
+
+	if manifest.FPS < 0 {
+		fmt.Printf("Ignoring negative fps in %s\n", filepath.Join(framesPath, ManifestFileName))
+		manifest.FPS = 0
+	}
+
+	manifest.Schedule = validateSchedule(manifest.Schedule)
+
+	return manifest, nil
+}
+
+func (m AnimationManifest) ResolvedFPS() float64 {
+	if m.FPS > 0 {
+		return m.FPS
+	}
+	return DefaultFPS
+}
+
+func (m AnimationManifest) FrameDelay() uint64 {
+	if m.FPS <= 0 {
+		return DefaultFrameDelay
+	}
+	return max(1, uint64(1000/m.FPS))
+}
+
+func (r DragRegion) Contains(x, y float64) bool {
+	return x >= r.X && x < r.X+r.W && y >= r.Y && y < r.Y+r.H
+}
@@ -0,0 +1,67 @@
+package AnimationEngine
+
+/*
+NaturalSort.go - Numeric-aware ordering of frame file names
+
+Digit runs compare by value, so frame2.png sorts before frame10.png.
+Names that are equal by value (e.g. 01.png and 1.png) fall back to
+lexical order to keep the result deterministic.
+
+Functions:
+- SortNatural: Sort strings in natural order
+- NaturalLess: Report whether a sorts before b in natural order
+- nextChunk: Split the leading digit or non-digit run from a string
+*/
+
+import (
+	"sort"
+	"strings"
+)
+
+func SortNatural(names []string) {
+	sort.SliceStable(names, func(i, j int) bool {
+		return NaturalLess(names[i], names[j])
+	})
+}
+
+func NaturalLess(a, b string) bool {
+	x, y := a, b
+	for x != "" && y != "" {
+		var cx, cy string
+		var dx, dy bool
+		cx, x, dx = nextChunk(x)
+		cy, y, dy = nextChunk(y)
+
+		if dx && dy {
+			nx := strings.TrimLeft(cx, "0")
+			ny := strings.TrimLeft(cy, "0")
+			if len(nx) != len(ny) {
+				return len(nx) < len(ny)
+			}
+			if nx != ny {
+				return nx < ny
+			}
+			continue
+		}
+
+		if cx != cy {
+			return cx < cy
+		}
+	}
+
+	if x != y {
+		return x == ""
+	}
+	return a < b
+}
+
+func nextChunk(s string) (chunk, rest string, digits bool) {
+	isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+
+	digits = isDigit(s[0])
+	i := 1
+	for i < len(s) && isDigit(s[i]) == digits {
+		i++
+	}
+	return s[:i], s[i:], digits
+}
@@ -0,0 +1,54 @@
+package AnimationEngine
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSortNatural(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []string
+		want []string
+	}{
+		{
+			"plain numbers",
+			[]string{"10.png", "2.png", "11.png", "1.png"},
+			[]string{"1.png", "2.png", "10.png", "11.png"},
+		},
+		{
+			"prefixed numbers",
+			[]string{"frame10.png", "frame2.png", "frame1.png"},
+			[]string{"frame1.png", "frame2.png", "frame10.png"},
+		},
+		{
+			"mixed prefixes",
+			[]string{"walk_10.png", "idle_2.png", "walk_2.png", "idle_10.png"},
+			[]string{"idle_2.png", "idle_10.png", "walk_2.png", "walk_10.png"},
+		},
+		{
+			"zero padding",
+			[]string{"010.png", "1.png", "01.png", "9.png"},
+			[]string{"01.png", "1.png", "9.png", "010.png"},
+		},
+		{
+			"several digit runs",
+			[]string{"a2_10.png", "a2_9.png", "a10_1.png"},
+			[]string{"a2_9.png", "a2_10.png", "a10_1.png"},
+		},
+		{
+			"prefix of another name",
+			[]string{"frame1.png", "frame", "frame1"},
+			[]string{"frame", "frame1", "frame1.png"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Clone(tt.in)
+			SortNatural(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SortNatural(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
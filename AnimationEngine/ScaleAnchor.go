@@ -0,0 +1,79 @@
+package AnimationEngine
+
+/*
+ScaleAnchor.go - Keep one point of a window fixed while the sprite resizes
+
+SDL resizes a window around its top-left corner. For other anchors Render
+moves the window by the difference so the anchor point lands where it was:
+half the size change for the center, and the full height change for the
+bottom edge, which keeps a character standing on the taskbar or resting on
+the floor at the same height while it scales.
+
+Functions:
+- (ScaleAnchor) IsValid: Check if an anchor is one of the supported values
+- (AnimationPlayer) SetScaleAnchor: Choose which point of the window stays put when it resizes
+- (AnimationPlayer) SetWindowMover: Route the window moves of anchored resizes through the caller
+- (AnimationPlayer) anchorOffset: Whole-pixel window move for a resize, carrying the remainder
+- anchorShift: Fractional window move that keeps an anchor in place across a resize
+*/
+
+import (
+	"math"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+type ScaleAnchor string
+
+const (
+	AnchorTopLeft      ScaleAnchor = "topLeft"
+	AnchorCenter       ScaleAnchor = "center"
+	AnchorBottomCenter ScaleAnchor = "bottomCenter"
+)
+
+func (anchor ScaleAnchor) IsValid() bool {
+	return anchor == AnchorTopLeft || anchor == AnchorCenter || anchor == AnchorBottomCenter
+}
+
+// SetScaleAnchor ignores unknown anchors.
+func (ap *AnimationPlayer) SetScaleAnchor(anchor ScaleAnchor) {
+	if anchor.IsValid() && anchor != ap.anchor {
+		ap.anchor = anchor
+		ap.anchorRest = sdl.FPoint{}
+	}
+}
+
+// SetWindowMover makes Render call move instead of SDL_SetWindowPosition,
+// so the owner of the window can tell these moves apart from the user
+// dragging it. nil restores moving the window directly.
+func (ap *AnimationPlayer) SetWindowMover(move func(x, y int32)) {
+	ap.moveWindow = move
+}
+
+// anchorOffset rounds to whole pixels and keeps what was rounded off for the
+// next resize, so the anchor stays within half a pixel over many small steps.
+func (ap *AnimationPlayer) anchorOffset(from, to sdl.Point) (int32, int32) {
+	dx, dy := anchorShift(ap.anchor, from, to)
+	if dx == 0 && dy == 0 {
+		return 0, 0
+	}
+
+	dx += ap.anchorRest.X
+	dy += ap.anchorRest.Y
+	moveX, moveY := int32(math.Round(float64(dx))), int32(math.Round(float64(dy)))
+	ap.anchorRest = sdl.FPoint{X: dx - float32(moveX), Y: dy - float32(moveY)}
+	return moveX, moveY
+}
+
+// anchorShift returns how far the window's top-left corner must move when
+// its size changes from `from` to `to`. Growing gives negative values.
+func anchorShift(anchor ScaleAnchor, from, to sdl.Point) (float32, float32) {
+	dw, dh := float32(from.X-to.X), float32(from.Y-to.Y)
+	switch anchor {
+	case AnchorCenter:
+		return dw / 2, dh / 2
+	case AnchorBottomCenter:
+		return dw / 2, dh
+	}
+	return 0, 0
+}
@@ -0,0 +1,49 @@
+package AnimationEngine
+
+import (
+	"testing"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+func TestAnchorShift(t *testing.T) {
+	small, large := sdl.Point{X: 100, Y: 80}, sdl.Point{X: 150, Y: 120}
+	tests := []struct {
+		anchor   ScaleAnchor
+		from, to sdl.Point
+		dx, dy   float32
+	}{
+		{AnchorTopLeft, small, large, 0, 0},
+		{AnchorTopLeft, large, small, 0, 0},
+		{AnchorCenter, small, large, -25, -20},
+		{AnchorCenter, large, small, 25, 20},
+		{AnchorBottomCenter, small, large, -25, -40},
+		{AnchorBottomCenter, large, small, 25, 40},
+		{AnchorBottomCenter, small, small, 0, 0},
+	}
+	for _, tt := range tests {
+		dx, dy := anchorShift(tt.anchor, tt.from, tt.to)
+		if dx != tt.dx || dy != tt.dy {
+			t.Errorf("anchorShift(%s, %v, %v) = %v, %v, want %v, %v",
+				tt.anchor, tt.from, tt.to, dx, dy, tt.dx, tt.dy)
+		}
+	}
+}
+
+func TestAnchorOffsetCarriesRemainder(t *testing.T) {
+	ap := &AnimationPlayer{anchor: AnchorCenter}
+
+	// Growing by one pixel at a time moves half a pixel per step, so the
+	// window should move one pixel every other step.
+	var moved int32
+	size := sdl.Point{X: 100, Y: 100}
+	for i := 0; i < 10; i++ {
+		next := sdl.Point{X: size.X + 1, Y: size.Y + 1}
+		dx, _ := ap.anchorOffset(size, next)
+		moved += dx
+		size = next
+	}
+	if moved != -5 {
+		t.Errorf("moved %d pixels over 10 one-pixel steps, want -5", moved)
+	}
+}
@@ -0,0 +1,73 @@
+package AnimationEngine
+
+/*
+Schedule.go - Time-of-day state schedule from animation.json
+
+Times are "HH:MM" in the machine's local timezone. A range whose end is
+before its start wraps past midnight (e.g. 22:00-07:00).
+
+Functions:
+- (ScheduleEntry) contains: Check if a minute-of-day falls inside the range
+- (AnimationManifest) ScheduledState: Get the state scheduled for a given time
+- validateSchedule: Drop entries with unparseable times or empty states
+- parseClock: Parse "HH:MM" into minutes since midnight
+*/
+
+import (
+	"fmt"
+	"time"
+)
+
+type ScheduleEntry struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	State string `json:"state"`
+
+	fromMin int
+	toMin   int
+}
+
+func (e ScheduleEntry) contains(minute int) bool {
+	if e.fromMin == e.toMin {
+		return true
+	}
+	if e.fromMin < e.toMin {
+		return minute >= e.fromMin && minute < e.toMin
+	}
+	return minute >= e.fromMin || minute < e.toMin
+}
+
+// ScheduledState returns the state of the first schedule entry covering t.
+func (m AnimationManifest) ScheduledState(t time.Time) (string, bool) {
+	t = t.Local()
+	minute := t.Hour()*60 + t.Minute()
+	for _, e := range m.Schedule {
+		if e.contains(minute) {
+			return e.State, true
+		}
+	}
+	return "", false
+}
+
+func validateSchedule(entries []ScheduleEntry) []ScheduleEntry {
+	valid := make([]ScheduleEntry, 0, len(entries))
+	for _, e := range entries {
+		from, errFrom := parseClock(e.From)
+		to, errTo := parseClock(e.To)
+		if errFrom != nil || errTo != nil || e.State == "" {
+			fmt.Printf("Ignoring invalid schedule entry %s-%s %q\n", e.From, e.To, e.State)
+			continue
+		}
+		e.fromMin, e.toMin = from, to
+		valid = append(valid, e)
+	}
+	return valid
+}
+
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q: %w", value, err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
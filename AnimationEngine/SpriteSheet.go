@@ -0,0 +1,113 @@
+package AnimationEngine
+
+/*
+SpriteSheet.go - Sprite-sheet characters described by spritesheet.json
+
+The sheet is decoded and uploaded once; each frame is a cell of the grid,
+read row by row, and rendered through a source rectangle.
+
+Functions:
+- LoadSpriteSheet: Read spritesheet.json from a directory if present
+- (SpriteSheet) cellRect: Get the source rectangle of a frame
+- decodeSpriteSheetFrames: Decode the sheet and slice it into frames
+*/
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+const SpriteSheetFileName = "spritesheet.json"
+
+type SpriteSheet struct {
+	Image       string `json:"image,omitempty"`
+	FrameWidth  int32  `json:"frameWidth"`
+	FrameHeight int32  `json:"frameHeight"`
+	Columns     int    `json:"columns"`
+	Rows        int    `json:"rows"`
+	Count       int    `json:"count"`
+}
+
+// LoadSpriteSheet returns nil without error when the directory has no spritesheet.json.
+func LoadSpriteSheet(dir string) (*SpriteSheet, error) {
+	data, err := os.ReadFile(filepath.Join(dir, SpriteSheetFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var sheet SpriteSheet
+	if err := json.Unmarshal(data, &sheet); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", SpriteSheetFileName, err)
+	}
+
+	if sheet.FrameWidth <= 0 || sheet.FrameHeight <= 0 || sheet.Columns <= 0 || sheet.Rows <= 0 {
+		return nil, fmt.Errorf("%s needs positive frameWidth, frameHeight, columns and rows", SpriteSheetFileName)
+	}
+
+	cells := sheet.Columns * sheet.Rows
+	if sheet.Count <= 0 || sheet.Count > cells {
+		sheet.Count = cells
+	}
+
+	if sheet.Image == "" {
+		images, _ := FindFrameFiles(dir)
+		if len(images) != 1 {
+			return nil, fmt.Errorf("%s has no image and the folder does not contain exactly one image", SpriteSheetFileName)
+		}
+		sheet.Image = filepath.Base(images[0])
+	}
+
+	return &sheet, nil
+}
+
+func (s SpriteSheet) cellRect(frame int) sdl.FRect {
+	col := int32(frame % s.Columns)
+	row := int32(frame / s.Columns)
+	return sdl.FRect{
+		X: float32(col * s.FrameWidth),
+		Y: float32(row * s.FrameHeight),
+		W: float32(s.FrameWidth),
+		H: float32(s.FrameHeight),
+	}
+}
+
+func decodeSpriteSheetFrames(dir string, sheet *SpriteSheet) (*decodedFrames, error) {
+	file := filepath.Join(dir, filepath.Base(sheet.Image))
+
+	decoded, err := decodeImageFile(file)
+	if err != nil {
+		return nil, err
+	}
+	img := toNRGBA(decoded)
+	sheetW, sheetH := int32(img.Rect.Dx()), int32(img.Rect.Dy())
+	mask := maskFromNRGBA(img)
+
+	frames := &decodedFrames{images: []*image.NRGBA{img}}
+	for i := 0; i < sheet.Count; i++ {
+		rect := sheet.cellRect(i)
+		if int32(rect.X+rect.W) > sheetW || int32(rect.Y+rect.H) > sheetH {
+			fmt.Printf("Warning: %s frame %d lies outside the %dx%d sheet, stopping at %d frames\n",
+				filepath.Base(file), i, sheetW, sheetH, i)
+			break
+		}
+		frames.imageIndex = append(frames.imageIndex, 0)
+		frames.originalSizes = append(frames.originalSizes, sdl.Point{X: sheet.FrameWidth, Y: sheet.FrameHeight})
+		frames.srcRects = append(frames.srcRects, rect)
+		frames.masks = append(frames.masks, mask)
+	}
+
+	if len(frames.originalSizes) == 0 {
+		return nil, fmt.Errorf("sprite sheet %s has no frames inside its bounds", filepath.Base(file))
+	}
+
+	fmt.Printf("Loaded: %s (%d frames of %dx%d)\n", filepath.Base(file), len(frames.originalSizes), sheet.FrameWidth, sheet.FrameHeight)
+	return frames, nil
+}
@@ -0,0 +1,272 @@
+package AnimationEngine
+
+/*
+TextureCache.go - Reference-counted frame cache shared by all windows
+
+Loading a frame set has two parts with different owners. Decoding the files
+into pixels, alpha masks and delays doesn't depend on a renderer. SDL
+textures belong to the renderer that created them, and every character
+window owns its own renderer, so textures are cached per (renderer,
+directory) and a second window of a character always uploads, and holds in
+VRAM, its own copy.
+
+What windows of the same character share is the decode: the masks, delays
+and frame sizes stay cached for as long as any window uses the directory,
+and the pixels are kept only while some window is still uploading them.
+Windows that load a character at the same time, such as a layout spawning
+several copies, decode it once; a window spawned later decodes it again.
+This saves decode time and mask memory only. Decoding holds a per-directory
+lock, so windows of different characters load in parallel.
+
+Functions:
+- acquireFrameSet: Get cached frames for a directory, decoding and uploading them on first use
+- releaseFrameSet: Drop a reference and destroy the textures when unused
+- acquireDecodedFrames: Get the decoded frames of a directory with their pixels, decoding them if needed
+- finishUpload: Free the decoded pixels once no window is still uploading them
+- releaseDecodedFrames: Drop a reference to decoded frames and free them when unused
+- uploadFrames: Create the textures of decoded frames on a renderer
+- destroyFrameSet: Destroy the textures of a frame set
+- toNRGBA: Convert a decoded image to zero-origin NRGBA pixels
+- textureFromNRGBA: Create an SDL texture from an NRGBA image
+*/
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// decodedFrames is the renderer-independent part of a frame set. It is
+// shared between windows; only images is ever modified once cached, and
+// only by finishUpload when nothing reads it.
+type decodedFrames struct {
+	// images is nil once the frames are uploaded and no window is waiting to.
+	images []*image.NRGBA
+	// imageIndex is the image of each frame; nil means frame i uses images[i].
+	imageIndex    []int
+	originalSizes []sdl.Point
+	frameDelays   []uint64
+	srcRects      []sdl.FRect
+	masks         []*AlphaMask
+}
+
+type frameCacheKey struct {
+	renderer *sdl.Renderer
+	dir      string
+}
+
+type frameCacheEntry struct {
+	set  *frameSet
+	refs int
+}
+
+// decodedCacheEntry counts references and pending uploads under
+// frameCacheMu. loadMu is held while decoding, so windows loading the same
+// directory at once wait for a single decode.
+type decodedCacheEntry struct {
+	loadMu    sync.Mutex
+	frames    *decodedFrames
+	refs      int
+	uploading int
+}
+
+var (
+	frameCacheMu sync.Mutex
+	frameCache   = make(map[frameCacheKey]*frameCacheEntry)
+	decodedCache = make(map[string]*decodedCacheEntry)
+)
+
+// acquireFrameSet only holds frameCacheMu for the bookkeeping, so loading
+// one directory doesn't hold up windows loading another.
+func acquireFrameSet(renderer *sdl.Renderer, dir string) (*frameSet, error) {
+	key := frameCacheKey{renderer: renderer, dir: dir}
+
+	frameCacheMu.Lock()
+	if entry, ok := frameCache[key]; ok {
+		entry.refs++
+		frameCacheMu.Unlock()
+		return entry.set, nil
+	}
+	frameCacheMu.Unlock()
+
+	// A renderer belongs to one window's thread, so nothing else can load
+	// this key while it is being uploaded.
+	frames, err := acquireDecodedFrames(dir)
+	if err != nil {
+		return nil, err
+	}
+	set, err := uploadFrames(renderer, frames)
+	finishUpload(dir)
+
+	frameCacheMu.Lock()
+	defer frameCacheMu.Unlock()
+	if err != nil {
+		releaseDecodedFrames(dir)
+		return nil, err
+	}
+	frameCache[key] = &frameCacheEntry{set: set, refs: 1}
+	return set, nil
+}
+
+func releaseFrameSet(renderer *sdl.Renderer, dir string) {
+	key := frameCacheKey{renderer: renderer, dir: dir}
+
+	frameCacheMu.Lock()
+	defer frameCacheMu.Unlock()
+
+	entry, ok := frameCache[key]
+	if !ok {
+		return
+	}
+
+	entry.refs--
+	if entry.refs > 0 {
+		return
+	}
+
+	destroyFrameSet(entry.set)
+	delete(frameCache, key)
+	releaseDecodedFrames(dir)
+}
+
+// acquireDecodedFrames takes one reference per frame set uploaded from the
+// frames, and marks the caller as uploading until it calls finishUpload.
+// The returned frames hold their pixels until then.
+func acquireDecodedFrames(dir string) (*decodedFrames, error) {
+	frameCacheMu.Lock()
+	entry, ok := decodedCache[dir]
+	if !ok {
+		entry = &decodedCacheEntry{}
+		decodedCache[dir] = entry
+	}
+	entry.refs++
+	entry.uploading++
+	frameCacheMu.Unlock()
+
+	entry.loadMu.Lock()
+	defer entry.loadMu.Unlock()
+
+	if entry.frames == nil || entry.frames.images == nil {
+		frames, err := decodeFrames(dir)
+		if err != nil {
+			finishUpload(dir)
+			frameCacheMu.Lock()
+			releaseDecodedFrames(dir)
+			frameCacheMu.Unlock()
+			return nil, err
+		}
+		// Frame sets already uploaded keep the masks they were built with.
+		entry.frames = frames
+	}
+	return entry.frames, nil
+}
+
+// finishUpload drops the pixels after the last pending upload, leaving the
+// masks, delays and sizes cached.
+func finishUpload(dir string) {
+	frameCacheMu.Lock()
+	defer frameCacheMu.Unlock()
+
+	entry, ok := decodedCache[dir]
+	if !ok {
+		return
+	}
+	entry.uploading--
+	if entry.uploading == 0 && entry.frames != nil {
+		entry.frames.images = nil
+	}
+}
+
+// releaseDecodedFrames must be called with frameCacheMu held.
+func releaseDecodedFrames(dir string) {
+	entry, ok := decodedCache[dir]
+	if !ok {
+		return
+	}
+
+	entry.refs--
+	if entry.refs <= 0 {
+		delete(decodedCache, dir)
+	}
+}
+
+// uploadFrames creates one texture per image, so sprite-sheet frames share
+// the sheet's texture. Nothing is left on the renderer when it fails.
+func uploadFrames(renderer *sdl.Renderer, frames *decodedFrames) (*frameSet, error) {
+	imageTextures := make([]*sdl.Texture, 0, len(frames.images))
+	for i, img := range frames.images {
+		texture := textureFromNRGBA(renderer, img)
+		if texture == nil {
+			for _, t := range imageTextures {
+				sdl.DestroyTexture(t)
+			}
+			return nil, fmt.Errorf("failed to create texture for image %d: %s", i, sdl.GetError())
+		}
+		imageTextures = append(imageTextures, texture)
+	}
+
+	set := &frameSet{
+		decodedFrames: frames,
+		textures:      make([]*sdl.Texture, len(frames.originalSizes)),
+	}
+	for i := range set.textures {
+		index := i
+		if frames.imageIndex != nil {
+			index = frames.imageIndex[i]
+		}
+		set.textures[i] = imageTextures[index]
+	}
+	return set, nil
+}
+
+// destroyFrameSet skips repeated pointers, since sprite-sheet frames share one texture.
+func destroyFrameSet(set *frameSet) {
+	destroyed := make(map[*sdl.Texture]bool)
+	for _, t := range set.textures {
+		if !destroyed[t] {
+			sdl.DestroyTexture(t)
+			destroyed[t] = true
+		}
+	}
+}
+
+// toNRGBA returns img itself when it is already zero-origin NRGBA, which is
+// what the PNG decoder produces for frames with transparency.
+func toNRGBA(img image.Image) *image.NRGBA {
+	if nrgba, ok := img.(*image.NRGBA); ok && nrgba.Rect.Min == (image.Point{}) {
+		return nrgba
+	}
+
+	bounds := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Rect, img, bounds.Min, draw.Src)
+	return dst
+}
+
+// textureFromNRGBA relies on SDL's RGBA32 format having the same
+// non-premultiplied byte order as image.NRGBA.
+func textureFromNRGBA(renderer *sdl.Renderer, img *image.NRGBA) *sdl.Texture {
+	size := img.Bounds().Size()
+	if size.X == 0 || size.Y == 0 {
+		return nil
+	}
+
+	surface := sdl.CreateSurfaceFrom(int32(size.X), int32(size.Y), sdl.PixelFormatRGBA32, unsafe.Pointer(&img.Pix[0]), int32(img.Stride))
+	if surface == nil {
+		return nil
+	}
+
+	texture := sdl.CreateTextureFromSurface(renderer, surface)
+	sdl.DestroySurface(surface)
+	runtime.KeepAlive(img)
+
+	if texture != nil {
+		sdl.SetTextureBlendMode(texture, sdl.BlendModeBlend)
+	}
+	return texture
+}
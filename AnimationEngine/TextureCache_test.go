@@ -0,0 +1,181 @@
+package AnimationEngine
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// writeTestFrames writes count square PNG frames with a transparent border.
+func writeTestFrames(tb testing.TB, count, size int) string {
+	tb.Helper()
+	dir := tb.TempDir()
+	for i := 0; i < count; i++ {
+		img := image.NewNRGBA(image.Rect(0, 0, size, size))
+		for y := size / 4; y < size*3/4; y++ {
+			for x := size / 4; x < size*3/4; x++ {
+				img.SetNRGBA(x, y, color.NRGBA{R: uint8(i), G: 128, B: 255, A: 255})
+			}
+		}
+
+		file, err := os.Create(filepath.Join(dir, fmt.Sprintf("%d.png", i+1)))
+		if err != nil {
+			tb.Fatal(err)
+		}
+		if err := png.Encode(file, img); err != nil {
+			tb.Fatal(err)
+		}
+		file.Close()
+	}
+	return dir
+}
+
+// acquireForTest acquires and finishes uploading like acquireFrameSet
+// without a renderer.
+func acquireForTest(tb testing.TB, dir string) *decodedFrames {
+	tb.Helper()
+	frames, err := acquireDecodedFrames(dir)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return frames
+}
+
+func releaseForTest(dir string) {
+	frameCacheMu.Lock()
+	releaseDecodedFrames(dir)
+	frameCacheMu.Unlock()
+}
+
+func TestDecodedFramesAreShared(t *testing.T) {
+	dir := writeTestFrames(t, 3, 16)
+
+	first := acquireForTest(t, dir)
+	second := acquireForTest(t, dir)
+	if first != second {
+		t.Fatal("second acquire during an upload decoded the frames again")
+	}
+	if len(first.images) != 3 || len(first.masks) != 3 {
+		t.Fatalf("got %d images and %d masks, want 3 each", len(first.images), len(first.masks))
+	}
+	if !first.masks[0].Opaque(8, 8) || first.masks[0].Opaque(0, 0) {
+		t.Error("mask doesn't follow the frame's alpha")
+	}
+
+	finishUpload(dir)
+	if first.images == nil {
+		t.Fatal("pixels freed while an upload was pending")
+	}
+	finishUpload(dir)
+	if first.images != nil {
+		t.Fatal("pixels kept after the last upload")
+	}
+	if len(first.masks) != 3 || len(first.originalSizes) != 3 {
+		t.Fatal("masks or frame sizes freed with the pixels")
+	}
+
+	// A window loading later needs the pixels again.
+	third := acquireForTest(t, dir)
+	if len(third.images) != 3 {
+		t.Fatalf("later acquire got %d images, want 3", len(third.images))
+	}
+	finishUpload(dir)
+
+	for i := 0; i < 2; i++ {
+		releaseForTest(dir)
+	}
+	if _, ok := decodedCache[dir]; !ok {
+		t.Fatal("frames freed while still referenced")
+	}
+	releaseForTest(dir)
+	if _, ok := decodedCache[dir]; ok {
+		t.Fatal("frames kept after the last release")
+	}
+}
+
+func TestConcurrentAcquireDecodesOnce(t *testing.T) {
+	dir := writeTestFrames(t, 3, 16)
+
+	const windows = 8
+	results := make(chan *decodedFrames, windows)
+	var wg sync.WaitGroup
+	for i := 0; i < windows; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			frames, err := acquireDecodedFrames(dir)
+			if err != nil {
+				t.Error(err)
+			}
+			results <- frames
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	first := <-results
+	for frames := range results {
+		if frames != first {
+			t.Error("windows loading at the same time decoded separately")
+		}
+	}
+	for i := 0; i < windows; i++ {
+		finishUpload(dir)
+		releaseForTest(dir)
+	}
+}
+
+func TestAcquireMissingDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+	if _, err := acquireDecodedFrames(dir); err == nil {
+		t.Fatal("acquired frames of a missing directory")
+	}
+	if _, ok := decodedCache[dir]; ok {
+		t.Fatal("failed acquire left a cache entry")
+	}
+}
+
+// BenchmarkAcquireDecodedFrames times the decode part of acquireFrameSet;
+// uploading and VRAM are not covered, since every window's renderer needs
+// its own textures. A window loading alone decodes every frame, while
+// windows loading the same character at the same time share one decode.
+func BenchmarkAcquireDecodedFrames(b *testing.B) {
+	dir := writeTestFrames(b, 24, 256)
+
+	load := func(windows int) {
+		var wg sync.WaitGroup
+		for i := 0; i < windows; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := acquireDecodedFrames(dir); err != nil {
+					b.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+		for i := 0; i < windows; i++ {
+			finishUpload(dir)
+			releaseForTest(dir)
+		}
+	}
+
+	for _, run := range []struct {
+		name    string
+		windows int
+	}{
+		{"one window", 1},
+		{"four windows at once", 4},
+	} {
+		b.Run(run.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				load(run.windows)
+			}
+		})
+	}
+}
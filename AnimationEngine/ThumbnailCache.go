@@ -0,0 +1,165 @@
+package AnimationEngine
+
+/*
+ThumbnailCache.go - Downscaled preview thumbnails cached on disk
+
+Thumbnails are PNG files named after a hash of the character name plus a
+hash of the preview frame's path, size and modification time, so a changed
+frame gets a new file and the old one is removed. Decoding and scaling use
+the Go image packages, so no SDL renderer is needed.
+
+Functions:
+- GetThumbnail: Return PNG thumbnail bytes for a preview frame, generating them if needed
+- ClearThumbnailCache: Remove every cached thumbnail
+- thumbnailKey: Cache file name prefix and full name for a preview frame
+- generateThumbnail: Decode, downscale and encode a preview frame
+- downscale: Box-filter an image to fit within a square
+- writeThumbnail: Write a thumbnail through a temp file and rename
+*/
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+)
+
+// ThumbnailSize is the longest side of a cached thumbnail in pixels.
+const ThumbnailSize = 128
+
+func GetThumbnail(cacheDir, characterName, previewPath string) ([]byte, error) {
+	info, err := os.Stat(previewPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read preview frame: %w", err)
+	}
+
+	prefix, name := thumbnailKey(characterName, previewPath, info)
+	cachePath := filepath.Join(cacheDir, name)
+	if data, err := os.ReadFile(cachePath); err == nil {
+		return data, nil
+	}
+
+	data, err := generateThumbnail(previewPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stale thumbnails of the same character are dropped before the new one
+	// is written. The prefix is hex, so it has no glob metacharacters.
+	if stale, err := filepath.Glob(filepath.Join(cacheDir, prefix+"_*.png")); err == nil {
+		for _, path := range stale {
+			os.Remove(path)
+		}
+	}
+	if err := writeThumbnail(cacheDir, cachePath, data); err != nil {
+		// The thumbnail is still usable; it is regenerated next time.
+		fmt.Printf("Warning: Could not cache thumbnail for %s: %v\n", characterName, err)
+	}
+	return data, nil
+}
+
+func ClearThumbnailCache(cacheDir string) error {
+	if err := os.RemoveAll(cacheDir); err != nil {
+		return fmt.Errorf("failed to clear thumbnail cache: %w", err)
+	}
+	return nil
+}
+
+func thumbnailKey(characterName, previewPath string, info os.FileInfo) (string, string) {
+	nameSum := sha1.Sum([]byte(characterName))
+	prefix := hex.EncodeToString(nameSum[:8])
+
+	frameSum := sha1.Sum([]byte(fmt.Sprintf("%s\x00%d\x00%d", previewPath, info.Size(), info.ModTime().UnixNano())))
+	return prefix, prefix + "_" + hex.EncodeToString(frameSum[:8]) + ".png"
+}
+
+func generateThumbnail(previewPath string) ([]byte, error) {
+	img, err := decodeImageFile(previewPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, downscale(img, ThumbnailSize)); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// downscale keeps the aspect ratio and never enlarges. Each output pixel
+// averages the source pixels it covers, which keeps thin sprite outlines
+// visible where nearest-neighbour sampling would drop them.
+func downscale(img image.Image, size int) image.Image {
+	src := img.Bounds()
+	width, height := src.Dx(), src.Dy()
+	if width <= size && height <= size {
+		return img
+	}
+
+	dstWidth, dstHeight := size, size
+	if width > height {
+		dstHeight = max(1, height*size/width)
+	} else {
+		dstWidth = max(1, width*size/height)
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0 := src.Min.Y + y*height/dstHeight
+		y1 := max(y0+1, src.Min.Y+(y+1)*height/dstHeight)
+		for x := 0; x < dstWidth; x++ {
+			x0 := src.Min.X + x*width/dstWidth
+			x1 := max(x0+1, src.Min.X+(x+1)*width/dstWidth)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			// The sums are premultiplied; divide by alpha to get NRGBA.
+			if a == 0 {
+				continue
+			}
+			dst.SetNRGBA(x, y, color.NRGBA{
+				R: uint8((r*0xff + a/2) / a),
+				G: uint8((g*0xff + a/2) / a),
+				B: uint8((b*0xff + a/2) / a),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
+
+func writeThumbnail(cacheDir, cachePath string, data []byte) error {
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return err
+	}
+
+	tempFile, err := os.CreateTemp(cacheDir, "thumb-*.tmp")
+	if err != nil {
+		return err
+	}
+	tempPath := tempFile.Name()
+
+	_, err = tempFile.Write(data)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tempPath, cachePath)
+	}
+	if err != nil {
+		os.Remove(tempPath)
+	}
+	return err
+}
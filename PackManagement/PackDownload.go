@@ -0,0 +1,143 @@
+package PackManagement
+
+/*
+PackDownload.go - Install .bfk packs shared as links
+
+Functions:
+- InstallPackFromURL: Download a pack over https and install it
+- downloadPack: Download a pack to a temp file with a size cap
+- packFileName: File name for a downloaded pack, which becomes its pack name unless it has a manifest
+- validatePackURL: Reject malformed and non-https pack URLs
+*/
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	maxPackDownloadBytes = 512 << 20
+	packDownloadTimeout  = 5 * time.Minute
+)
+
+var packHTTPClient = &http.Client{
+	Timeout: packDownloadTimeout,
+	// Redirects are held to the same https rule as the original link.
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return fmt.Errorf("too many redirects")
+		}
+		return validatePackURL(req.URL.String())
+	},
+}
+
+func InstallPackFromURL(ctx context.Context, rawURL, framesPath string) error {
+	tempPath, err := downloadPack(ctx, rawURL)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(filepath.Dir(tempPath))
+
+	if _, err := ValidateBfkPack(tempPath); err != nil {
+		return fmt.Errorf("downloaded file is not a valid pack: %w", err)
+	}
+
+	return InstallPack(ctx, tempPath, framesPath)
+}
+
+// downloadPack returns the path of the downloaded file inside a new temp
+// directory, which the caller must remove. Nothing is left behind when it fails.
+func downloadPack(ctx context.Context, rawURL string) (string, error) {
+	if err := validatePackURL(rawURL); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid pack URL: %w", err)
+	}
+	resp, err := packHTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download pack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download pack: server returned %s", resp.Status)
+	}
+	if resp.ContentLength > maxPackDownloadBytes {
+		return "", fmt.Errorf("pack is too large (%d MB, limit %d MB)", resp.ContentLength>>20, maxPackDownloadBytes>>20)
+	}
+
+	// A temp directory keeps the link's file name, so the pack name isn't random.
+	tempDir, err := os.MkdirTemp("", "boccho-pack-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	tempPath := filepath.Join(tempDir, packFileName(resp.Request.URL))
+
+	tempFile, err := os.Create(tempPath)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	// Read one byte past the limit to tell a pack of exactly the limit from a larger one.
+	written, err := io.Copy(tempFile, io.LimitReader(resp.Body, maxPackDownloadBytes+1))
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && written > maxPackDownloadBytes {
+		err = fmt.Errorf("pack is larger than the %d MB limit", maxPackDownloadBytes>>20)
+	}
+	if err != nil {
+		os.RemoveAll(tempDir)
+		return "", fmt.Errorf("failed to download pack: %w", err)
+	}
+
+	return tempPath, nil
+}
+
+// packFileName keeps the link's file name when it names a pack. Other links
+// get the host plus a hash of the whole URL, so two packs downloaded from
+// e.g. download?id=1 and download?id=2 don't register as the same pack,
+// while installing the same link again replaces its earlier install.
+func packFileName(u *url.URL) string {
+	name := path.Base(u.Path)
+	if isPackFile(name) && !strings.ContainsAny(name, `/\`) {
+		return name
+	}
+
+	host := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.' || r == '-' {
+			return r
+		}
+		return '-'
+	}, strings.ToLower(u.Hostname()))
+	sum := sha256.Sum256([]byte(u.String()))
+	return fmt.Sprintf("%s-%s.bfk", host, hex.EncodeToString(sum[:4]))
+}
+
+func validatePackURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid pack URL: %w", err)
+	}
+	if parsed.Scheme != "https" {
+		return fmt.Errorf("pack URL must use https")
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("pack URL has no host")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package PackManagement
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestPackFileName(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string // empty when a derived name is expected
+	}{
+		{"https://example.com/packs/cats.bfk", "cats.bfk"},
+		{"https://example.com/packs/Cats.ZIP", "Cats.ZIP"},
+		{"https://example.com/packs/cats.bfk?token=1", "cats.bfk"},
+		{"https://example.com/download?id=1", ""},
+		{"https://example.com/", ""},
+		{"https://Example.COM:8443", ""},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := packFileName(u)
+		if tt.want != "" {
+			if got != tt.want {
+				t.Errorf("packFileName(%s) = %q, want %q", tt.url, got, tt.want)
+			}
+			continue
+		}
+		if !strings.HasPrefix(got, "example.com-") || !isPackFile(got) {
+			t.Errorf("packFileName(%s) = %q, want a name derived from the host", tt.url, got)
+		}
+	}
+}
+
+func TestPackFileNameIsUniquePerURL(t *testing.T) {
+	names := map[string]string{}
+	for _, raw := range []string{
+		"https://example.com/download?id=1",
+		"https://example.com/download?id=2",
+		"https://example.com/other",
+		"https://mirror.example.com/download?id=1",
+	} {
+		u, _ := url.Parse(raw)
+		name := packFileName(u)
+		if other, ok := names[name]; ok {
+			t.Errorf("%s and %s both get pack file name %q", other, raw, name)
+		}
+		names[name] = raw
+	}
+
+	u, _ := url.Parse("https://example.com/download?id=1")
+	if packFileName(u) != packFileName(u) {
+		t.Error("the same link got different names")
+	}
+}
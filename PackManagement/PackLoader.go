@@ -5,66 +5,115 @@ PackLoader.go - Validate and preview .bfk pack files
 
 Functions:
 - ValidateBfkPack: Open zip, find character folders with frames
+- validateBfkPack: ValidateBfkPack with a cap on the size of the files it reads
 - GetPackPreviewImage: Extract first frame as base64 for preview
 - GetPackInfo: Return pack metadata including characters and preview
+- ListPackContents: List every entry of a pack with its size
+- isFrameFile: Check if a file name has a supported frame extension
+- isPackFile: Check if a file name has a pack extension (.bfk or .zip)
+- packNameFromPath: Derive a pack name from its file name
+- imageDataURL: Encode image bytes as a data URL by file extension
 */
 
 import (
 	"archive/zip"
 	"encoding/base64"
 	"fmt"
-	"io"
+	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 )
 
 type PackInfo struct {
-	FilePath     string   `json:"filePath"`
-	PackName     string   `json:"packName"`
-	Characters   []string `json:"characters"`
-	PreviewImage string   `json:"previewImage"`
-	Error        string   `json:"error,omitempty"`
+	FilePath     string         `json:"filePath"`
+	PackName     string         `json:"packName"`
+	Characters   []string       `json:"characters"`
+	FrameCounts  map[string]int `json:"frameCounts"`
+	PreviewImage string         `json:"previewImage"`
+	// CharacterPreviews maps each character to its first frame as a data URL.
+	CharacterPreviews map[string]string `json:"characterPreviews"`
+	Author            string            `json:"author,omitempty"`
+	Version           string            `json:"version,omitempty"`
+	Description       string            `json:"description,omitempty"`
+	Conflicts         []string          `json:"conflicts,omitempty"`
+	Error             string            `json:"error,omitempty"`
+}
+
+func isFrameFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".png" || ext == ".jpg" || ext == ".jpeg"
+}
+
+// Packs are plain zip archives, so .zip works the same as .bfk.
+func isPackFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".bfk" || ext == ".zip"
+}
+
+func packNameFromPath(filePath string) string {
+	name := filepath.Base(filePath)
+	if isPackFile(name) {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name
 }
 
 func ValidateBfkPack(filePath string) (*PackInfo, error) {
+	return validateBfkPack(filePath, DefaultMaxPackFileBytes)
+}
+
+// validateBfkPack reads the manifest preview and each character's first
+// frame into memory, so it runs before the install size caps are checked;
+// files over maxFile bytes are not read and get no preview.
+func validateBfkPack(filePath string, maxFile int64) (*PackInfo, error) {
 	reader, err := zip.OpenReader(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open pack: %w", err)
 	}
 	defer reader.Close()
 
-	packName := strings.TrimSuffix(filepath.Base(filePath), ".bfk")
-	characters := make(map[string]bool)
-	var firstImagePath string
-	var firstImageData []byte
+	packName := packNameFromPath(filePath)
+	previewImage := ""
+
+	// A broken manifest only loses the metadata; the pack itself still installs.
+	manifest, err := readManifest(&reader.Reader)
+	if err != nil {
+		fmt.Printf("Warning: Ignoring manifest of %s: %v\n", filepath.Base(filePath), err)
+		manifest = nil
+	}
+	if manifest != nil && manifest.Preview != "" {
+		if file := findZipFile(&reader.Reader, manifest.Preview); file != nil {
+			if data, err := readZipFile(file, maxFile); err == nil {
+				previewImage = imageDataURL(file.Name, data)
+			}
+		} else {
+			fmt.Printf("Warning: Manifest preview %q not found in %s\n", manifest.Preview, filepath.Base(filePath))
+		}
+	}
+
+	characters := make(map[string]int)
+	previews := make(map[string]string)
 
 	for _, file := range reader.File {
-		if file.FileInfo().IsDir() {
+		if file.FileInfo().IsDir() || file.Mode()&os.ModeSymlink != 0 {
 			continue
 		}
 
-		parts := strings.Split(file.Name, "/")
+		parts := strings.Split(normalizeEntryName(file.Name), "/")
 		if len(parts) < 2 {
 			continue
 		}
 
 		charName := parts[0]
 		fileName := parts[len(parts)-1]
-		ext := strings.ToLower(filepath.Ext(fileName))
-
-		if ext == ".png" || ext == ".jpg" || ext == ".jpeg" {
-			characters[charName] = true
-
-			if firstImageData == nil {
-				rc, err := file.Open()
-				if err == nil {
-					data, err := io.ReadAll(rc)
-					rc.Close()
-					if err == nil {
-						firstImagePath = file.Name
-						firstImageData = data
-					}
+
+		if isFrameFile(fileName) {
+			characters[charName]++
+
+			if _, ok := previews[charName]; !ok {
+				if data, err := readZipFile(file, maxFile); err == nil {
+					previews[charName] = imageDataURL(file.Name, data)
 				}
 			}
 		}
@@ -80,22 +129,71 @@ func ValidateBfkPack(filePath string) (*PackInfo, error) {
 	}
 	sort.Strings(charList)
 
-	previewImage := ""
-	if firstImageData != nil {
-		ext := strings.ToLower(filepath.Ext(firstImagePath))
-		mimeType := "image/png"
-		if ext == ".jpg" || ext == ".jpeg" {
-			mimeType = "image/jpeg"
+	if previewImage == "" {
+		previewImage = previews[charList[0]]
+	}
+
+	info := &PackInfo{
+		FilePath:          filePath,
+		PackName:          packName,
+		Characters:        charList,
+		FrameCounts:       characters,
+		PreviewImage:      previewImage,
+		CharacterPreviews: previews,
+	}
+	if manifest != nil {
+		if manifest.Name != "" {
+			info.PackName = manifest.Name
 		}
-		previewImage = fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(firstImageData))
+		info.Author = manifest.Author
+		info.Version = manifest.Version
+		info.Description = manifest.Description
+	}
+	return info, nil
+}
+
+func imageDataURL(name string, data []byte) string {
+	ext := strings.ToLower(filepath.Ext(name))
+	mimeType := "image/png"
+	switch ext {
+	case ".jpg", ".jpeg":
+		mimeType = "image/jpeg"
+	case ".gif":
+		mimeType = "image/gif"
+	}
+	return fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(data))
+}
+
+// PackEntry describes one file of a pack as ListPackContents reports it.
+type PackEntry struct {
+	Name    string `json:"name"`
+	Size    int64  `json:"size"` // uncompressed bytes
+	IsDir   bool   `json:"isDir"`
+	IsFrame bool   `json:"isFrame"`
+}
+
+func ListPackContents(bfkPath string) ([]PackEntry, error) {
+	reader, err := zip.OpenReader(bfkPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pack: %w", err)
 	}
+	defer reader.Close()
 
-	return &PackInfo{
-		FilePath:     filePath,
-		PackName:     packName,
-		Characters:   charList,
-		PreviewImage: previewImage,
-	}, nil
+	entries := make([]PackEntry, 0, len(reader.File))
+	for _, file := range reader.File {
+		name := normalizeEntryName(file.Name)
+		isDir := file.FileInfo().IsDir()
+		parts := strings.Split(strings.TrimSuffix(name, "/"), "/")
+
+		entries = append(entries, PackEntry{
+			Name:  name,
+			Size:  int64(file.UncompressedSize64),
+			IsDir: isDir,
+			// Same rule as ValidateBfkPack: frames sit inside a character folder.
+			IsFrame: !isDir && len(parts) >= 2 && isFrameFile(parts[len(parts)-1]),
+		})
+	}
+	return entries, nil
 }
 
 func GetPackInfo(filePath string) PackInfo {
@@ -0,0 +1,84 @@
+package PackManagement
+
+/*
+PackManifest.go - Optional manifest.json metadata at the root of a .bfk pack
+
+Functions:
+- readManifest: Parse manifest.json from an open pack, if present
+- readZipFile: Read the contents of a file in an open pack, up to a size limit
+- findZipFile: Look up a file in an open pack by its path
+*/
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+const (
+	manifestFileName = "manifest.json"
+	maxManifestBytes = 1 << 20
+)
+
+type PackManifest struct {
+	Name        string `json:"name"`
+	Author      string `json:"author"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	Preview     string `json:"preview"` // path of an image inside the pack
+}
+
+// readManifest returns nil without an error when the pack has no manifest.
+func readManifest(reader *zip.Reader) (*PackManifest, error) {
+	file := findZipFile(reader, manifestFileName)
+	if file == nil {
+		return nil, nil
+	}
+
+	data, err := readZipFile(file, maxManifestBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", manifestFileName, err)
+	}
+
+	var manifest PackManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", manifestFileName, err)
+	}
+	return &manifest, nil
+}
+
+// readZipFile returns errFileTooLarge for entries over limit bytes. Headers
+// can understate the size, so the read itself is capped as well.
+func readZipFile(file *zip.File, limit int64) ([]byte, error) {
+	if file.UncompressedSize64 > uint64(limit) {
+		return nil, errFileTooLarge
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, errFileTooLarge
+	}
+	return data, nil
+}
+
+func findZipFile(reader *zip.Reader, name string) *zip.File {
+	name = path.Clean(strings.TrimPrefix(normalizeEntryName(name), "/"))
+	for _, file := range reader.File {
+		if !file.FileInfo().IsDir() && path.Clean(normalizeEntryName(file.Name)) == name {
+			return file
+		}
+	}
+	return nil
+}
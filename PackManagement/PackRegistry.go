@@ -0,0 +1,134 @@
+package PackManagement
+
+/*
+PackRegistry.go - Record which pack installed each character folder
+
+installed.json in the app data dir maps pack names to the character folders
+they installed. A folder belongs to the pack that wrote it last, so it is
+removed from the previous owner's list when another pack overwrites it.
+
+Functions:
+- LoadInstalledPacks: Read installed.json, empty if it doesn't exist yet
+- saveInstalledPacks: Write installed.json
+- recordInstall: Assign character folders to a pack after installing it
+- GetPackCharacters: List the character folders a pack currently owns
+- ForgetPack: Remove a pack from installed.json after uninstalling it
+*/
+
+import (
+	"boccho-ui/config"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+)
+
+type InstalledPacks struct {
+	Packs map[string][]string `json:"packs"`
+}
+
+// registryMu serializes read-modify-write cycles of installed.json.
+var registryMu sync.Mutex
+
+func getRegistryPath() string {
+	return filepath.Join(config.GetAppDataDir(), "installed.json")
+}
+
+func LoadInstalledPacks() (InstalledPacks, error) {
+	registry := InstalledPacks{Packs: map[string][]string{}}
+
+	data, err := os.ReadFile(getRegistryPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return registry, nil
+		}
+		return registry, err
+	}
+
+	if err := json.Unmarshal(data, &registry); err != nil {
+		return InstalledPacks{Packs: map[string][]string{}}, fmt.Errorf("failed to parse installed.json: %w", err)
+	}
+	if registry.Packs == nil {
+		registry.Packs = map[string][]string{}
+	}
+	return registry, nil
+}
+
+func saveInstalledPacks(registry InstalledPacks) error {
+	if err := os.MkdirAll(filepath.Dir(getRegistryPath()), 0755); err != nil {
+		return fmt.Errorf("failed to create app data directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(registry, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(getRegistryPath(), data, 0644)
+}
+
+func recordInstall(packName string, characters []string) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry, err := LoadInstalledPacks()
+	if err != nil {
+		return err
+	}
+
+	for pack, owned := range registry.Packs {
+		if pack == packName {
+			continue
+		}
+		owned = slices.DeleteFunc(owned, func(name string) bool {
+			return slices.Contains(characters, name)
+		})
+		if len(owned) == 0 {
+			delete(registry.Packs, pack)
+		} else {
+			registry.Packs[pack] = owned
+		}
+	}
+
+	owned := registry.Packs[packName]
+	for _, name := range characters {
+		if !slices.Contains(owned, name) {
+			owned = append(owned, name)
+		}
+	}
+	sort.Strings(owned)
+	registry.Packs[packName] = owned
+
+	return saveInstalledPacks(registry)
+}
+
+func GetPackCharacters(packName string) ([]string, error) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry, err := LoadInstalledPacks()
+	if err != nil {
+		return nil, err
+	}
+
+	owned, ok := registry.Packs[packName]
+	if !ok {
+		return nil, fmt.Errorf("pack %q is not installed", packName)
+	}
+	return slices.Clone(owned), nil
+}
+
+func ForgetPack(packName string) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry, err := LoadInstalledPacks()
+	if err != nil {
+		return err
+	}
+
+	delete(registry.Packs, packName)
+	return saveInstalledPacks(registry)
+}
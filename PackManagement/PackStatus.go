@@ -0,0 +1,126 @@
+package PackManagement
+
+/*
+PackStatus.go - Compare a .bfk pack against installed characters
+
+Functions:
+- GetPackInstallStatus: Report per-character install state of a pack
+- CheckConflicts: List pack characters that already exist in the Frames directory
+- findConflicts: CheckConflicts for an already validated pack
+- countInstalledFrames: Count frame files of an installed character, state folders included
+*/
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	StatusInstalled    = "installed"
+	StatusNotInstalled = "notInstalled"
+	StatusDiffers      = "differs"
+)
+
+type CharacterInstallStatus struct {
+	Name            string `json:"name"`
+	Status          string `json:"status"`
+	PackFrames      int    `json:"packFrames"`
+	InstalledFrames int    `json:"installedFrames"`
+}
+
+type PackInstallStatus struct {
+	FilePath   string                   `json:"filePath"`
+	PackName   string                   `json:"packName"`
+	Characters []CharacterInstallStatus `json:"characters"`
+	Error      string                   `json:"error,omitempty"`
+}
+
+func GetPackInstallStatus(filePath, framesPath string) PackInstallStatus {
+	info, err := ValidateBfkPack(filePath)
+	if err != nil {
+		return PackInstallStatus{
+			FilePath:   filePath,
+			Characters: []CharacterInstallStatus{},
+			Error:      err.Error(),
+		}
+	}
+
+	statuses := make([]CharacterInstallStatus, 0, len(info.Characters))
+	for _, name := range info.Characters {
+		packFrames := info.FrameCounts[name]
+		installedFrames, exists := countInstalledFrames(filepath.Join(framesPath, name))
+
+		status := StatusNotInstalled
+		if exists {
+			status = StatusInstalled
+			if installedFrames != packFrames {
+				status = StatusDiffers
+			}
+		}
+
+		statuses = append(statuses, CharacterInstallStatus{
+			Name:            name,
+			Status:          status,
+			PackFrames:      packFrames,
+			InstalledFrames: installedFrames,
+		})
+	}
+
+	return PackInstallStatus{
+		FilePath:   filePath,
+		PackName:   info.PackName,
+		Characters: statuses,
+	}
+}
+
+// CheckConflicts returns the names, sorted, of characters that installing the
+// pack would overwrite.
+func CheckConflicts(bfkPath, framesPath string) ([]string, error) {
+	info, err := ValidateBfkPack(bfkPath)
+	if err != nil {
+		return nil, err
+	}
+	return findConflicts(info, framesPath)
+}
+
+func findConflicts(info *PackInfo, framesPath string) ([]string, error) {
+	conflicts := []string{}
+	for _, name := range info.Characters {
+		stat, err := os.Stat(filepath.Join(framesPath, name))
+		if err == nil {
+			if stat.IsDir() {
+				conflicts = append(conflicts, name)
+			}
+			continue
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to check %s: %w", name, err)
+		}
+	}
+	return conflicts, nil
+}
+
+// countInstalledFrames counts at every depth, like ValidateBfkPack does for
+// the pack, so characters with state subfolders compare equal once installed.
+func countInstalledFrames(charPath string) (int, bool) {
+	if stat, err := os.Stat(charPath); err != nil || !stat.IsDir() {
+		return 0, false
+	}
+
+	count := 0
+	err := filepath.WalkDir(charPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() && isFrameFile(entry.Name()) {
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("Warning: Could not count frames in %s: %v\n", charPath, err)
+	}
+	return count, true
+}
@@ -4,65 +4,269 @@ package PackManagement
 Packmanager.go - Install .bfk packs to Frames directory
 
 Functions:
-- InstallPack: Extract character folders from zip to Frames directory
+- InstallPack: Extract character folders from zip to Frames directory, overwriting
+- InstallPackWithOptions: Extract a pack, choosing whether to overwrite installed characters
+- installedCharacters: Pack characters that were not skipped
+- extractFile: Copy one zip entry to disk, enforcing the per-file size cap
+- normalizeEntryName: Convert a zip entry name to forward slashes
+- safeEntryPath: Resolve a zip entry inside the Frames directory or reject it
+- (extraction) rollback: Remove what a failed install created
 */
 
 import (
 	"archive/zip"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 )
 
-func InstallPack(bfkPath, framesPath string) error {
+const (
+	DefaultMaxPackBytes     int64 = 2 << 30   // total uncompressed size of a pack
+	DefaultMaxPackFileBytes int64 = 256 << 20 // uncompressed size of one entry
+)
+
+var errFileTooLarge = errors.New("file exceeds the size limit")
+
+type InstallOptions struct {
+	// Overwrite replaces files of characters that are already installed.
+	// When false those characters are skipped entirely.
+	Overwrite bool
+	// Progress, if set, is called after each extracted file with the number
+	// of files done so far and the total to extract.
+	Progress func(done, total int)
+	// MaxTotalBytes and MaxFileBytes cap the uncompressed size of the pack
+	// and of each entry. Zero uses DefaultMaxPackBytes and DefaultMaxPackFileBytes.
+	MaxTotalBytes int64
+	MaxFileBytes  int64
+}
+
+// extraction records what an install created so a failure can undo it.
+// Files that already existed and were overwritten cannot be restored.
+type extraction struct {
+	newRoots     []string
+	createdFiles []string
+}
+
+func (e *extraction) rollback() {
+	for i := len(e.createdFiles) - 1; i >= 0; i-- {
+		os.Remove(e.createdFiles[i])
+	}
+	for _, root := range e.newRoots {
+		os.RemoveAll(root)
+	}
+}
+
+func InstallPack(ctx context.Context, bfkPath, framesPath string) error {
+	return InstallPackWithOptions(ctx, bfkPath, framesPath, InstallOptions{Overwrite: true})
+}
+
+// InstallPackWithOptions checks ctx between files. When it is cancelled the
+// files extracted so far are rolled back and ctx.Err() is returned wrapped.
+func InstallPackWithOptions(ctx context.Context, bfkPath, framesPath string, opts InstallOptions) error {
+	maxTotal := opts.MaxTotalBytes
+	if maxTotal <= 0 {
+		maxTotal = DefaultMaxPackBytes
+	}
+	maxFile := opts.MaxFileBytes
+	if maxFile <= 0 {
+		maxFile = DefaultMaxPackFileBytes
+	}
+
+	info, err := validateBfkPack(bfkPath, maxFile)
+	if err != nil {
+		return err
+	}
+
+	skip := map[string]bool{}
+	if !opts.Overwrite {
+		conflicts, err := findConflicts(info, framesPath)
+		if err != nil {
+			return err
+		}
+		for _, name := range conflicts {
+			skip[name] = true
+			fmt.Printf("Skipping installed character %s\n", name)
+		}
+	}
+
 	reader, err := zip.OpenReader(bfkPath)
 	if err != nil {
 		return fmt.Errorf("failed to open pack: %w", err)
 	}
 	defer reader.Close()
 
-	cleanFramesPath := filepath.Clean(framesPath) + string(os.PathSeparator)
-
+	// Filter first so the progress total only counts files that are written,
+	// and so oversized packs are rejected before anything is extracted.
+	type entry struct {
+		file     *zip.File
+		root     string
+		destPath string
+	}
+	var entries []entry
+	total := 0
+	var declaredBytes uint64
 	for _, file := range reader.File {
-		destPath := filepath.Join(framesPath, file.Name)
+		// A pack is never expected to contain links; following one on a
+		// later install could write outside the Frames directory.
+		if file.Mode()&os.ModeSymlink != 0 {
+			fmt.Printf("Skipping symlink %s in pack\n", file.Name)
+			continue
+		}
 
-		if !strings.HasPrefix(filepath.Clean(destPath)+string(os.PathSeparator), cleanFramesPath) &&
-			filepath.Clean(destPath) != filepath.Clean(framesPath) {
+		destPath, rel, err := safeEntryPath(framesPath, normalizeEntryName(file.Name))
+		if err != nil {
+			return err
+		}
+		root := strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]
+		if rel == "." || rel == manifestFileName || skip[root] {
+			continue
+		}
+
+		entries = append(entries, entry{file: file, root: filepath.Join(framesPath, root), destPath: destPath})
+		if file.FileInfo().IsDir() {
 			continue
 		}
+		total++
+
+		if file.UncompressedSize64 > uint64(maxFile) {
+			return fmt.Errorf("pack entry %s is %d MB, over the %d MB per-file limit",
+				file.Name, file.UncompressedSize64>>20, maxFile>>20)
+		}
+		declaredBytes += file.UncompressedSize64
+		if declaredBytes > uint64(maxTotal) {
+			return fmt.Errorf("pack is over the %d MB size limit", maxTotal>>20)
+		}
+	}
+
+	var ext extraction
+	for _, e := range entries {
+		if _, err := os.Stat(e.root); os.IsNotExist(err) && !slices.Contains(ext.newRoots, e.root) {
+			ext.newRoots = append(ext.newRoots, e.root)
+		}
+	}
+
+	done := 0
+	if opts.Progress != nil {
+		opts.Progress(done, total)
+	}
+
+	// Entry headers can lie about their size, so the written bytes are
+	// counted as well.
+	var writtenBytes int64
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			ext.rollback()
+			return fmt.Errorf("install cancelled: %w", err)
+		}
+
+		file, destPath := e.file, e.destPath
 
 		if file.FileInfo().IsDir() {
 			if err := os.MkdirAll(destPath, 0755); err != nil {
+				ext.rollback()
 				return fmt.Errorf("failed to create directory %s: %w", destPath, err)
 			}
 			continue
 		}
 
 		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+			ext.rollback()
 			return fmt.Errorf("failed to create parent directory: %w", err)
 		}
 
-		srcFile, err := file.Open()
-		if err != nil {
-			return fmt.Errorf("failed to open file in zip: %w", err)
+		if _, err := os.Stat(destPath); os.IsNotExist(err) {
+			ext.createdFiles = append(ext.createdFiles, destPath)
 		}
 
-		dstFile, err := os.Create(destPath)
+		written, err := extractFile(file, destPath, min(maxFile, maxTotal-writtenBytes))
+		writtenBytes += written
+		if errors.Is(err, errFileTooLarge) {
+			ext.rollback()
+			if writtenBytes >= maxTotal {
+				return fmt.Errorf("pack is over the %d MB size limit", maxTotal>>20)
+			}
+			return fmt.Errorf("pack entry %s is over the %d MB per-file limit", file.Name, maxFile>>20)
+		}
 		if err != nil {
-			srcFile.Close()
-			return fmt.Errorf("failed to create file %s: %w", destPath, err)
+			ext.rollback()
+			return err
 		}
 
-		_, err = io.Copy(dstFile, srcFile)
-		srcFile.Close()
-		dstFile.Close()
-
-		if err != nil {
-			return fmt.Errorf("failed to extract file %s: %w", destPath, err)
+		done++
+		if opts.Progress != nil {
+			opts.Progress(done, total)
 		}
 	}
 
+	// The files are in place either way; a stale record only affects uninstall.
+	if err := recordInstall(info.PackName, installedCharacters(info, skip)); err != nil {
+		fmt.Printf("Warning: Could not record installed pack %s: %v\n", info.PackName, err)
+	}
+
 	return nil
 }
+
+// extractFile returns the number of bytes written, and errFileTooLarge if the
+// entry holds more than limit bytes.
+func extractFile(file *zip.File, destPath string, limit int64) (int64, error) {
+	srcFile, err := file.Open()
+	if err != nil {
+		return 0, fmt.Errorf("failed to open file in zip: %w", err)
+	}
+	defer srcFile.Close()
+
+	dstFile, err := os.Create(destPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create file %s: %w", destPath, err)
+	}
+
+	// One byte past the limit tells an entry of exactly the limit from a larger one.
+	written, err := io.Copy(dstFile, io.LimitReader(srcFile, limit+1))
+	if closeErr := dstFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return written, fmt.Errorf("failed to extract file %s: %w", destPath, err)
+	}
+	if written > limit {
+		return written, errFileTooLarge
+	}
+	return written, nil
+}
+
+// normalizeEntryName makes backslash-separated names from Windows zip tools
+// split the same way on every OS.
+func normalizeEntryName(name string) string {
+	return strings.ReplaceAll(name, `\`, "/")
+}
+
+// safeEntryPath returns the destination of an entry and its cleaned path
+// relative to framesPath. It rejects absolute names and names that leave
+// framesPath, such as "../../evil.txt" or "a/../../b".
+func safeEntryPath(framesPath, name string) (string, string, error) {
+	if strings.HasPrefix(name, "/") || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", "", fmt.Errorf("pack entry %q has an absolute path", name)
+	}
+
+	destPath := filepath.Join(framesPath, filepath.FromSlash(name))
+	rel, err := filepath.Rel(framesPath, destPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		return "", "", fmt.Errorf("pack entry %q points outside the Frames directory", name)
+	}
+	return destPath, rel, nil
+}
+
+func installedCharacters(info *PackInfo, skip map[string]bool) []string {
+	characters := make([]string, 0, len(info.Characters))
+	for _, name := range info.Characters {
+		if !skip[name] {
+			characters = append(characters, name)
+		}
+	}
+	return characters
+}
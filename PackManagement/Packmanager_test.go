@@ -0,0 +1,362 @@
+package PackManagement
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"context"
+	"errors"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testEntry is one file of a pack built by writeTestPack. A non-zero
+// declaredSize is written to the header instead of the real size.
+type testEntry struct {
+	name         string
+	data         []byte
+	declaredSize uint64
+	symlink      bool
+}
+
+func writeTestPack(t *testing.T, entries []testEntry) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.bfk")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	for _, e := range entries {
+		if e.declaredSize != 0 {
+			writeRawEntry(t, zw, e)
+			continue
+		}
+
+		header := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		if e.symlink {
+			header.SetMode(os.ModeSymlink | 0777)
+		}
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(e.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// writeRawEntry writes an entry whose header lies about its uncompressed size.
+func writeRawEntry(t *testing.T, zw *zip.Writer, e testEntry) {
+	t.Helper()
+	var compressed bytes.Buffer
+	fw, err := flate.NewWriter(&compressed, flate.BestCompression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write(e.data)
+	fw.Close()
+
+	w, err := zw.CreateRaw(&zip.FileHeader{
+		Name:               e.name,
+		Method:             zip.Deflate,
+		CRC32:              crc32.ChecksumIEEE(e.data),
+		CompressedSize64:   uint64(compressed.Len()),
+		UncompressedSize64: e.declaredSize,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(compressed.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestFrames returns an empty Frames directory and keeps the install
+// registry out of the real home directory.
+func newTestFrames(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("LOCALAPPDATA", home)
+	framesPath := filepath.Join(t.TempDir(), "Frames")
+	if err := os.MkdirAll(framesPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	return framesPath
+}
+
+func assertEmptyDir(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		t.Errorf("unexpected %s left in %s", entry.Name(), dir)
+	}
+}
+
+func TestInstallPackRejectsOversizedFile(t *testing.T) {
+	framesPath := newTestFrames(t)
+	// A megabyte of zeros compresses to about a kilobyte.
+	pack := writeTestPack(t, []testEntry{
+		{name: "Hero/1.png", data: []byte("frame")},
+		{name: "Hero/2.png", data: make([]byte, 1<<20)},
+	})
+
+	err := InstallPackWithOptions(context.Background(), pack, framesPath, InstallOptions{
+		Overwrite:    true,
+		MaxFileBytes: 64 << 10,
+	})
+	if err == nil || !strings.Contains(err.Error(), "per-file limit") {
+		t.Fatalf("got %v, want a per-file limit error", err)
+	}
+	assertEmptyDir(t, framesPath)
+}
+
+func TestInstallPackRejectsOversizedPack(t *testing.T) {
+	framesPath := newTestFrames(t)
+	pack := writeTestPack(t, []testEntry{
+		{name: "Hero/1.png", data: make([]byte, 512<<10)},
+		{name: "Hero/2.png", data: make([]byte, 512<<10)},
+		{name: "Hero/3.png", data: make([]byte, 512<<10)},
+	})
+
+	err := InstallPackWithOptions(context.Background(), pack, framesPath, InstallOptions{
+		Overwrite:     true,
+		MaxTotalBytes: 1 << 20,
+	})
+	if err == nil || !strings.Contains(err.Error(), "size limit") {
+		t.Fatalf("got %v, want a size limit error", err)
+	}
+	assertEmptyDir(t, framesPath)
+}
+
+// TestExtractFileStopsAtLimit covers the written-bytes check that backs up
+// the header check when an entry holds more than its header says.
+func TestExtractFileStopsAtLimit(t *testing.T) {
+	pack := writeTestPack(t, []testEntry{{name: "Hero/1.png", data: make([]byte, 1<<20)}})
+	reader, err := zip.OpenReader(pack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	const limit = 64 << 10
+	written, err := extractFile(reader.File[0], filepath.Join(t.TempDir(), "1.png"), limit)
+	if !errors.Is(err, errFileTooLarge) {
+		t.Fatalf("got %v, want errFileTooLarge", err)
+	}
+	if written != limit+1 {
+		t.Errorf("wrote %d bytes, want to stop at %d", written, limit+1)
+	}
+}
+
+// archive/zip itself fails an entry once it reads past the declared size,
+// so the install must fail and roll back either way.
+func TestInstallPackCatchesUnderstatedSize(t *testing.T) {
+	framesPath := newTestFrames(t)
+	pack := writeTestPack(t, []testEntry{
+		{name: "Hero/1.png", data: []byte("frame")},
+		{name: "Hero/2.png", data: make([]byte, 1<<20), declaredSize: 10},
+	})
+
+	err := InstallPackWithOptions(context.Background(), pack, framesPath, InstallOptions{
+		Overwrite:    true,
+		MaxFileBytes: 64 << 10,
+	})
+	if err == nil {
+		t.Fatal("pack with an understated entry size installed")
+	}
+	assertEmptyDir(t, framesPath)
+}
+
+func TestInstallPackRollbackKeepsExistingCharacters(t *testing.T) {
+	framesPath := newTestFrames(t)
+	existing := filepath.Join(framesPath, "Old", "1.png")
+	if err := os.MkdirAll(filepath.Dir(existing), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte("installed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pack := writeTestPack(t, []testEntry{
+		{name: "Old/2.png", data: []byte("frame")},
+		{name: "New/1.png", data: []byte("frame")},
+		{name: "New/2.png", data: make([]byte, 1<<20), declaredSize: 10},
+	})
+
+	err := InstallPackWithOptions(context.Background(), pack, framesPath, InstallOptions{Overwrite: true})
+	if err == nil {
+		t.Fatal("pack with an understated entry size installed")
+	}
+
+	if _, err := os.Stat(filepath.Join(framesPath, "New")); !os.IsNotExist(err) {
+		t.Error("rollback kept the new character folder")
+	}
+	if _, err := os.Stat(filepath.Join(framesPath, "Old", "2.png")); !os.IsNotExist(err) {
+		t.Error("rollback kept a file created in an existing character")
+	}
+	if _, err := os.Stat(existing); err != nil {
+		t.Errorf("rollback removed an installed file: %v", err)
+	}
+}
+
+func TestNormalizeEntryName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Hero/1.png", "Hero/1.png"},
+		{`Hero\1.png`, "Hero/1.png"},
+		{`Hero\idle\1.png`, "Hero/idle/1.png"},
+		{`..\..\evil.txt`, "../../evil.txt"},
+	}
+	for _, tt := range tests {
+		if got := normalizeEntryName(tt.name); got != tt.want {
+			t.Errorf("normalizeEntryName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSafeEntryPath(t *testing.T) {
+	framesPath := filepath.Join(t.TempDir(), "Frames")
+	tests := []struct {
+		name    string
+		wantRel string // empty when the name must be rejected
+	}{
+		{"Hero/1.png", filepath.Join("Hero", "1.png")},
+		{"Hero/idle/1.png", filepath.Join("Hero", "idle", "1.png")},
+		{"Hero/../Other/1.png", filepath.Join("Other", "1.png")},
+		{"Hero/", "Hero"},
+		{"manifest.json", "manifest.json"},
+		{"../../evil.txt", ""},
+		{"a/../../b", ""},
+		{"..", ""},
+		{"/etc/passwd", ""},
+		{normalizeEntryName(`..\..\evil.txt`), ""},
+	}
+	for _, tt := range tests {
+		destPath, rel, err := safeEntryPath(framesPath, tt.name)
+		if tt.wantRel == "" {
+			if err == nil {
+				t.Errorf("safeEntryPath(%q) = %q, want an error", tt.name, destPath)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("safeEntryPath(%q): %v", tt.name, err)
+			continue
+		}
+		if rel != tt.wantRel || destPath != filepath.Join(framesPath, tt.wantRel) {
+			t.Errorf("safeEntryPath(%q) = %q, %q, want %q", tt.name, destPath, rel, tt.wantRel)
+		}
+	}
+}
+
+func TestInstallPackRejectsEscapingEntries(t *testing.T) {
+	for _, name := range []string{"../../evil.txt", "a/../../b", `..\..\evil.txt`} {
+		t.Run(name, func(t *testing.T) {
+			framesPath := newTestFrames(t)
+			pack := writeTestPack(t, []testEntry{
+				{name: "Hero/1.png", data: []byte("frame")},
+				{name: name, data: []byte("evil")},
+			})
+
+			if err := InstallPack(context.Background(), pack, framesPath); err == nil {
+				t.Fatal("pack with an escaping entry installed")
+			}
+			assertEmptyDir(t, framesPath)
+		})
+	}
+}
+
+func TestInstallPackSkipsSymlinks(t *testing.T) {
+	framesPath := newTestFrames(t)
+	pack := writeTestPack(t, []testEntry{
+		{name: "Hero/1.png", data: []byte("frame")},
+		{name: "Hero/link", data: []byte("../../outside"), symlink: true},
+	})
+
+	if err := InstallPack(context.Background(), pack, framesPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Lstat(filepath.Join(framesPath, "Hero", "link")); !os.IsNotExist(err) {
+		t.Error("symlink entry was extracted")
+	}
+	if _, err := os.Stat(filepath.Join(framesPath, "Hero", "1.png")); err != nil {
+		t.Errorf("frame next to the symlink was not installed: %v", err)
+	}
+}
+
+func TestInstallPackAcceptsBackslashNames(t *testing.T) {
+	framesPath := newTestFrames(t)
+	pack := writeTestPack(t, []testEntry{{name: `Hero\idle\1.png`, data: []byte("frame")}})
+
+	if err := InstallPack(context.Background(), pack, framesPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(framesPath, "Hero", "idle", "1.png")); err != nil {
+		t.Errorf("backslash entry was not installed under its folders: %v", err)
+	}
+}
+
+func TestReadZipFileStopsAtLimit(t *testing.T) {
+	pack := writeTestPack(t, []testEntry{
+		{name: "Hero/1.png", data: make([]byte, 1<<20)},
+		{name: "Hero/2.png", data: make([]byte, 1<<20), declaredSize: 10},
+	})
+	reader, err := zip.OpenReader(pack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		if _, err := readZipFile(file, 64<<10); err == nil {
+			t.Errorf("read all of %s past the limit", file.Name)
+		}
+	}
+}
+
+// A high-ratio first frame is read for the character preview before the
+// install caps apply, so the preview read must be capped too.
+func TestInstallPackRejectsHighRatioFirstFrame(t *testing.T) {
+	framesPath := newTestFrames(t)
+	pack := writeTestPack(t, []testEntry{
+		{name: "Hero/1.png", data: make([]byte, 4<<20)},
+		{name: "Other/1.png", data: []byte("frame")},
+	})
+
+	info, err := validateBfkPack(pack, 64<<10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := info.CharacterPreviews["Hero"]; ok {
+		t.Error("oversized first frame was read for the preview")
+	}
+	if _, ok := info.CharacterPreviews["Other"]; !ok {
+		t.Error("small first frame got no preview")
+	}
+
+	err = InstallPackWithOptions(context.Background(), pack, framesPath, InstallOptions{
+		Overwrite:    true,
+		MaxFileBytes: 64 << 10,
+	})
+	if err == nil || !strings.Contains(err.Error(), "per-file limit") {
+		t.Fatalf("got %v, want a per-file limit error", err)
+	}
+	assertEmptyDir(t, framesPath)
+}
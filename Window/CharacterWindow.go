@@ -5,49 +5,261 @@ CharacterWindow.go - SDL3 transparent borderless window for displaying animated
 
 SDL requires its event loop to run on a locked OS thread for proper event handling on Windows.
 This implementation uses runtime.LockOSThread() and channels for thread-safe communication.
+Every window thread polls SDL's shared event queue; events.go hands each window the
+events addressed to it, whichever thread polled them.
+A panic in the render loop is recovered; with auto-restart enabled the window is recreated
+at its last position and scale a bounded number of times.
 
 Functions:
 - NewCharacterWindow: Create new character window instance
 - (CharacterWindow) Start: Launch window in dedicated OS thread
+- (CharacterWindow) SetDefaultScale: Set the scale a double-click resets the window to
+- (CharacterWindow) SetAutoRestart: Enable recreating the window after a render panic
+- (CharacterWindow) OnCrash: Register a callback invoked when the render loop panics
+- (CharacterWindow) OnContextMenu: Register a callback invoked on right-click
+- (CharacterWindow) OnClose: Register a callback invoked when the window thread exits
+- (CharacterWindow) OnFail: Register a callback invoked when the window, renderer or frames fail to load
 - (CharacterWindow) Close: Signal window to close via channel
-- (CharacterWindow) SetScale: Thread-safe scale adjustment via channel
+- (CharacterWindow) SetScale: Thread-safe scale adjustment, keeping only the latest value (latest.go)
+- (CharacterWindow) SetReverse: Thread-safe playback direction toggle, keeping only the latest value
+- (CharacterWindow) SetPaused: Thread-safe pause/resume, keeping only the latest value
+- (CharacterWindow) SeekTo: Thread-safe frame seek via channel
+- (CharacterWindow) Capture: Copy the next rendered frame as an image (capture.go)
+- (CharacterWindow) GetStats: Get measured FPS and texture usage
+- (CharacterWindow) GetCurrentFrame: Get the frame index last rendered
+- (CharacterWindow) GetRenderInfo: Get the frame index, frame count and size last rendered
+- (CharacterWindow) SetFlipHorizontal: Thread-safe horizontal mirror toggle, keeping only the latest value
+- (CharacterWindow) SetOpacity: Thread-safe sprite opacity adjustment, keeping only the latest value
+- (CharacterWindow) SetTint: Thread-safe sprite color modulation, keeping only the latest value
+- (CharacterWindow) SetBackground: Thread-safe background color behind the sprite, keeping only the latest value
+- (CharacterWindow) SetState: Thread-safe animation state switch, keeping only the latest value
+- (CharacterWindow) SetSpeed: Thread-safe playback speed adjustment, keeping only the latest value
+- (CharacterWindow) SetPosition: Thread-safe window move, keeping only the latest value
+- (CharacterWindow) SetClickThrough: Toggle whether clicks on the window are ignored for dragging
+- (CharacterWindow) SetLocked: Toggle whether the window can be dragged
+- (CharacterWindow) SetWindowOpacity: Thread-safe whole-window opacity adjustment, keeping only the latest value
+- (CharacterWindow) SetAlwaysOnTop: Thread-safe always-on-top toggle, keeping only the latest value
+- (CharacterWindow) BringToFront / SendToBack: Thread-safe stacking order change via channel
+- (CharacterWindow) SetGravity: Thread-safe gravity toggle, keeping only the latest value
+- (CharacterWindow) SetBehavior: Thread-safe behavior switch (idle/walk), keeping only the latest value
+- (CharacterWindow) SetIdleState: Thread-safe choice of the state shown after the idle timeout (idle.go)
+- (CharacterWindow) SetScaleAnchor: Thread-safe per-window scale anchor override, keeping only the latest value
+- (CharacterWindow) SetVisible: Thread-safe hide/show, keeping only the latest value and the textures loaded
+- (CharacterWindow) SetInitialPosition: Set where the window appears when started
+- (CharacterWindow) SetInitialDisplay: Set which display the window is centered on when started
+- (CharacterWindow) SetInitialAlwaysOnTop: Set whether the window is created always on top
+- (CharacterWindow) SetInitialCenter: Center the window on a screen point once its size is known
+- (CharacterWindow) GetPosition: Get the last known window position
+- (CharacterWindow) IsVisible: Check if the window is shown
+- (CharacterWindow) GetSettings / ApplySettings: Read and restore the settings saved with layouts (settings.go)
 - (CharacterWindow) IsRunning: Check if window is still active
+- (CharacterWindow) IsDone: Check if window thread has exited (not just initializing)
+- (CharacterWindow) Done: Channel closed when window thread exits, for waiting with a timeout
+- (CharacterWindow) Ready: Channel closed once the window has loaded its frames and started rendering
+- (CharacterWindow) GetError: Get why the window failed to start, empty if it didn't
+- (CharacterWindow) fail: Record a startup failure and notify the OnFail callback
+- (CharacterWindow) createRenderer: Create a renderer, falling back to the software renderer
 - (CharacterWindow) GetID: Get unique window identifier
+- (CharacterWindow) SetCharacterSource: Thread-safe rename of the character shown and reloaded by the window
 */
 
 import (
 	"boccho-ui/AnimationEngine"
 	"fmt"
 	"runtime"
+	"runtime/debug"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/jupiterrider/purego-sdl3/sdl"
 )
 
-type CharacterWindow struct {
-	id            string
+const (
+	MaxCrashRestarts = 3
+	scheduleInterval = 30 * time.Second
+	statsInterval    = uint64(time.Second)
+)
+
+type CrashInfo struct {
+	WindowID      string `json:"windowId"`
+	CharacterName string `json:"characterName"`
+	Reason        string `json:"reason"`
+	Attempt       int    `json:"attempt"`
+	Restarting    bool   `json:"restarting"`
+}
+
+// FailureInfo is reported when a window could not be created at all, as
+// opposed to CrashInfo for a render loop that panicked.
+type FailureInfo struct {
+	WindowID      string `json:"windowId"`
+	CharacterName string `json:"characterName"`
+	Reason        string `json:"reason"`
+}
+
+// ContextMenuInfo is sent on right-click so the main window can draw a menu
+// at the cursor; X and Y are global screen coordinates.
+type ContextMenuInfo struct {
+	WindowID      string  `json:"windowId"`
+	CharacterName string  `json:"characterName"`
+	X             float32 `json:"x"`
+	Y             float32 `json:"y"`
+}
+
+type WindowStats struct {
+	FPS          float64 `json:"fps"`
+	FrameCount   int     `json:"frameCount"`
+	TextureCount int     `json:"textureCount"`
+	VRAMBytes    int64   `json:"vramBytes"`
+	Renderer     string  `json:"renderer"` // SDL render driver in use, e.g. "direct3d11" or "software"
+}
+
+// RenderInfo is published by the render thread whenever one of its fields
+// changes, so readers never see a frame index from one state paired with
+// the frame count of another.
+type RenderInfo struct {
+	FrameIndex int     `json:"frameIndex"`
+	FrameCount int     `json:"frameCount"`
+	Width      int32   `json:"width"`  // scaled, in pixels
+	Height     int32   `json:"height"` // scaled, in pixels
+	Scale      float64 `json:"scale"`
+}
+
+// characterSource is swapped as a whole so name and path always match.
+type characterSource struct {
 	characterName string
 	framesPath    string
+}
+
+type CharacterWindow struct {
+	id            string
+	source        atomic.Pointer[characterSource]
 	running       atomic.Bool
 	closeChan     chan struct{}
 	doneChan      chan struct{}
-	scaleChan     chan float64
+	readyChan     chan struct{}
+	readyOnce     sync.Once
+	failure       atomic.Pointer[string]
+	scale         *latestValue[float64]
+	reverse       *latestValue[bool]
+	paused        *latestValue[bool]
+	seekChan      chan int
+	flip          *latestValue[bool]
+	opacity       *latestValue[float64]
+	tint          *latestValue[sdl.Color]
+	state         *latestValue[string]
+	speed         *latestValue[float64]
+	position      *latestValue[sdl.Point]
+	winOpacity    *latestValue[float32]
+	background    *latestValue[sdl.Color]
+	onTop         *latestValue[bool]
+	stackChan     chan bool
+	gravityOn     *latestValue[bool]
+	behavior      *latestValue[string]
+	idleState     *latestValue[string]
+	anchor        *latestValue[AnimationEngine.ScaleAnchor]
+	shown         *latestValue[bool]
+	title         *latestValue[string]
+	captureChan   chan chan captureResult
+	currentFrame  atomic.Int32
 	currentScale  atomic.Value
+	lastPosition  atomic.Value
+	clickThrough  atomic.Bool
+	locked        atomic.Bool
+	visible       atomic.Bool
+	stats         atomic.Value
+	renderInfo    atomic.Pointer[RenderInfo]
+	settings      atomic.Pointer[WindowSettings]
+	displayIndex  int
+	initialCenter *sdl.Point
+	windowOpacity float32
+	bgColor       sdl.Color                   // render thread only; zero keeps the window fully transparent
+	scaleAnchor   AnimationEngine.ScaleAnchor // render thread only; "" follows GetScaleAnchor
+	alwaysOnTop   bool
+	sentToBack    bool
+	drag          dragTracker
+	gravity       gravityState
+	walk          walkState
+	idle          idleTracker
+	defaultScale  float64
+	autoRestart   bool
+	onCrash       func(CrashInfo)
+	onContextMenu func(ContextMenuInfo)
+	onClose       func(id string)
+	onFail        func(FailureInfo)
 }
 
-func NewCharacterWindow(id, characterName, framesPath string) *CharacterWindow {
+func NewCharacterWindow(id, characterName, framesPath string, scale float64) *CharacterWindow {
 	cw := &CharacterWindow{
 		id:            id,
-		characterName: characterName,
-		framesPath:    framesPath,
 		closeChan:     make(chan struct{}),
 		doneChan:      make(chan struct{}),
-		scaleChan:     make(chan float64, 10),
+		readyChan:     make(chan struct{}),
+		scale:         newLatestValue[float64](),
+		reverse:       newLatestValue[bool](),
+		paused:        newLatestValue[bool](),
+		seekChan:      make(chan int, 10),
+		flip:          newLatestValue[bool](),
+		opacity:       newLatestValue[float64](),
+		tint:          newLatestValue[sdl.Color](),
+		state:         newLatestValue[string](),
+		speed:         newLatestValue[float64](),
+		position:      newLatestValue[sdl.Point](),
+		winOpacity:    newLatestValue[float32](),
+		background:    newLatestValue[sdl.Color](),
+		onTop:         newLatestValue[bool](),
+		stackChan:     make(chan bool, 10),
+		gravityOn:     newLatestValue[bool](),
+		behavior:      newLatestValue[string](),
+		idleState:     newLatestValue[string](),
+		anchor:        newLatestValue[AnimationEngine.ScaleAnchor](),
+		shown:         newLatestValue[bool](),
+		title:         newLatestValue[string](),
+		captureChan:   make(chan chan captureResult, 10),
+		displayIndex:  -1,
+		windowOpacity: 1,
+		alwaysOnTop:   true,
+		defaultScale:  scale,
 	}
-	cw.currentScale.Store(0.51)
+	cw.source.Store(&characterSource{characterName: characterName, framesPath: framesPath})
+	cw.currentScale.Store(scale)
+	cw.visible.Store(true)
 	return cw
 }
 
+// SetDefaultScale must be called before Start.
+func (cw *CharacterWindow) SetDefaultScale(scale float64) {
+	cw.defaultScale = scale
+}
+
+func (cw *CharacterWindow) SetAutoRestart(enabled bool) {
+	cw.autoRestart = enabled
+}
+
+func (cw *CharacterWindow) OnCrash(handler func(CrashInfo)) {
+	cw.onCrash = handler
+}
+
+func (cw *CharacterWindow) OnContextMenu(handler func(ContextMenuInfo)) {
+	cw.onContextMenu = handler
+}
+
+func (cw *CharacterWindow) OnClose(handler func(id string)) {
+	cw.onClose = handler
+}
+
+func (cw *CharacterWindow) OnFail(handler func(FailureInfo)) {
+	cw.onFail = handler
+}
+
+func (cw *CharacterWindow) fail(reason string) {
+	fmt.Printf("[%s] %s\n", cw.id, reason)
+	cw.failure.Store(&reason)
+	if cw.onFail != nil {
+		cw.onFail(FailureInfo{WindowID: cw.id, CharacterName: cw.GetCharacterName(), Reason: reason})
+	}
+}
+
 func (cw *CharacterWindow) Start() {
 	go cw.runInOSThread()
 }
@@ -58,87 +270,405 @@ func (cw *CharacterWindow) runInOSThread() {
 	defer close(cw.doneChan)
 
 	cw.running.Store(true)
-	defer cw.running.Store(false)
+	defer func() {
+		cw.running.Store(false)
+		if cw.onClose != nil {
+			cw.onClose(cw.id)
+		}
+	}()
 
-	title := fmt.Sprintf("Boccho - %s", cw.characterName)
+	for attempt := 1; ; attempt++ {
+		reason := cw.runWindowLoop()
+		if reason == "" {
+			return
+		}
+
+		restarting := cw.autoRestart && attempt <= MaxCrashRestarts && !cw.isClosing()
+		if cw.onCrash != nil {
+			cw.onCrash(CrashInfo{
+				WindowID:      cw.id,
+				CharacterName: cw.GetCharacterName(),
+				Reason:        reason,
+				Attempt:       attempt,
+				Restarting:    restarting,
+			})
+		}
+		if !restarting {
+			return
+		}
+		fmt.Printf("[%s] Restarting window (attempt %d/%d)\n", cw.id, attempt, MaxCrashRestarts)
+	}
+}
+
+// runWindowLoop creates the window and runs the render loop until it closes.
+// It returns a non-empty reason only when the loop panicked.
+func (cw *CharacterWindow) runWindowLoop() (crashReason string) {
+	defer func() {
+		if r := recover(); r != nil {
+			crashReason = fmt.Sprint(r)
+			fmt.Printf("[%s] Render thread panicked: %v\n%s\n", cw.id, r, debug.Stack())
+		}
+	}()
+
+	title := windowTitle(cw.GetCharacterName())
 	winW, winH := int32(400), int32(400)
 
-	flags := sdl.WindowTransparent | sdl.WindowAlwaysOnTop | sdl.WindowBorderless
+	flags := sdl.WindowTransparent | sdl.WindowBorderless
+	if cw.alwaysOnTop && !cw.sentToBack {
+		flags |= sdl.WindowAlwaysOnTop
+	}
+	if !cw.visible.Load() {
+		flags |= sdl.WindowHidden
+	}
 
 	window := sdl.CreateWindow(title, winW, winH, flags)
 	if window == nil {
-		fmt.Printf("[%s] Failed to create window: %s\n", cw.id, sdl.GetError())
+		cw.fail(fmt.Sprintf("Failed to create window: %s", sdl.GetError()))
 		return
 	}
 	defer sdl.DestroyWindow(window)
+	// Registered right away so events from creating the window aren't
+	// dropped by other windows' threads.
+	windowID := sdl.GetWindowID(window)
+	registerEventQueue(windowID)
+	defer unregisterEventQueue(windowID)
+
+	if pos, ok := cw.lastPosition.Load().(sdl.Point); ok {
+		// Saved positions may point at a display that is no longer connected
+		pos = clampPosition(sdl.Rect{X: pos.X, Y: pos.Y, W: winW, H: winH}, getAllDisplayBounds(), minVisiblePixels)
+		cw.lastPosition.Store(cw.drag.moveTo(window, pos.X, pos.Y))
+	} else if pos, ok := centerInDisplay(cw.displayIndex, winW, winH); ok {
+		cw.lastPosition.Store(cw.drag.moveTo(window, pos.X, pos.Y))
+	} else {
+		var x, y int32
+		if sdl.GetWindowPosition(window, &x, &y) {
+			cw.lastPosition.Store(sdl.Point{X: x, Y: y})
+		}
+	}
+
+	// windowOpacity is only touched on this thread, so it survives a restart
+	if cw.windowOpacity < 1 {
+		sdl.SetWindowOpacity(window, cw.windowOpacity)
+	}
 
 	if !sdl.SetWindowHitTest(window, hitTestCallback, nil) {
 		fmt.Printf("[%s] Warning: Could not set hit test callback: %s\n", cw.id, sdl.GetError())
 	}
 
-	renderer := sdl.CreateRenderer(window, "")
-	if renderer == nil {
-		fmt.Printf("[%s] Failed to create renderer: %s\n", cw.id, sdl.GetError())
+	renderer, err := cw.createRenderer(window)
+	if err != nil {
+		cw.fail(fmt.Sprintf("Failed to create renderer: %v", err))
 		return
 	}
 	defer sdl.DestroyRenderer(renderer)
 
 	sdl.SetRenderDrawBlendMode(renderer, sdl.BlendModeBlend)
 
-	animation := AnimationEngine.NewAnimationPlayer(cw.framesPath)
+	animation := AnimationEngine.NewAnimationPlayer(cw.source.Load().framesPath, cw.GetScale())
 	if err := animation.LoadFrames(renderer); err != nil {
-		fmt.Printf("[%s] Failed to load frames: %v\n", cw.id, err)
+		cw.fail(fmt.Sprintf("Failed to load frames: %v", err))
 		return
 	}
 	defer animation.Cleanup()
+	// Anchored resizes move the window; going through the drag tracker keeps
+	// them from pausing gravity and walking or waking an idle character.
+	animation.SetWindowMover(func(x, y int32) {
+		cw.lastPosition.Store(cw.drag.moveTo(window, x, y))
+	})
+
+	// Centering needs the sprite size, which is only known once frames load.
+	// Cleared afterwards so a restart reuses the last position instead.
+	if cw.initialCenter != nil {
+		w, h := animation.GetScaledSize()
+		pos := centerOnPoint(*cw.initialCenter, w, h, getAllDisplayBounds())
+		cw.lastPosition.Store(cw.drag.moveTo(window, pos.X, pos.Y))
+		cw.initialCenter = nil
+	}
+
+	// Double-clicking the sprite resets the scale. Presses on the sprite start
+	// a drag, so they arrive through the hit test rather than as events, and
+	// never while the window is locked or click-through.
+	var clicks clickTracker
+	hitState := &hitTestState{
+		dragRegion:   animation.GetManifest().DragRegion,
+		clickThrough: &cw.clickThrough,
+		locked:       &cw.locked,
+		opaqueAt:     animation.IsOpaqueAt,
+		onDragPress: func(x, y float32) {
+			cw.idle.interact(sdl.GetTicksNS(), animation)
+			if clicks.press(x, y) {
+				animation.SetScale(cw.defaultScale)
+				cw.currentScale.Store(animation.GetTargetScale())
+			}
+		},
+	}
+	registerHitTest(window, hitState)
+	defer unregisterHitTest(window)
+
+	stats := WindowStats{FrameCount: animation.FrameCount(), Renderer: sdl.GetRendererName(renderer)}
+	stats.TextureCount, stats.VRAMBytes = animation.TextureStats()
+	cw.stats.Store(stats)
+	statsStart, statsFrames := sdl.GetTicksNS(), 0
+	var renderInfo RenderInfo
+	publishRenderInfo := func() {
+		info := RenderInfo{
+			FrameIndex: animation.GetCurrentFrame(),
+			FrameCount: animation.FrameCount(),
+			Scale:      animation.GetScale(),
+		}
+		info.Width, info.Height = animation.GetScaledSize()
+		if info != renderInfo {
+			renderInfo = info
+			cw.renderInfo.Store(&info)
+		}
+	}
+	publishRenderInfo()
+	cw.publishSettings(animation)
 
 	fmt.Printf("[%s] Character window started\n", cw.id)
-	fmt.Println("  Controls: Arrow Up/Down = Scale, Escape = Close")
+	cw.readyOnce.Do(func() { close(cw.readyChan) })
+	fmt.Println("  Controls: keyBindings in config (default Arrow Up/Down = Scale, Escape = Close)")
+
+	manifest := animation.GetManifest()
+	scheduledState := ""
+	var lastScheduleCheck time.Time
 
-	var event sdl.Event
+	var events []sdl.Event
+	var pendingCaptures []chan captureResult
+	// A restarted window starts over in its default state, awake.
+	cw.idle = idleTracker{state: cw.idle.state, lastInteraction: sdl.GetTicksNS()}
 	for {
-		select {
-		case <-cw.closeChan:
-			fmt.Printf("[%s] Received close signal\n", cw.id)
-			return
-		case newScale := <-cw.scaleChan:
-			animation.SetScale(newScale)
-			cw.currentScale.Store(newScale)
-			fmt.Printf("[%s] Scale set to: %.2f\n", cw.id, newScale)
-		default:
+		frameStart := sdl.GetTicksNS()
+		animation.SetAnimatedScale(IsAnimatedScale())
+		if cw.scaleAnchor != "" {
+			animation.SetScaleAnchor(cw.scaleAnchor)
+		} else {
+			animation.SetScaleAnchor(GetScaleAnchor())
+		}
+
+		// Drain everything pending so a burst of setters is applied in one
+		// frame; settings keep only their latest value, commands queue up.
+	pending:
+		for {
+			select {
+			case <-cw.closeChan:
+				fmt.Printf("[%s] Received close signal\n", cw.id)
+				return
+			case <-cw.scale.Ready():
+				animation.SetScale(cw.scale.Take())
+				cw.currentScale.Store(animation.GetTargetScale())
+				fmt.Printf("[%s] Scale set to: %.2f\n", cw.id, animation.GetTargetScale())
+			case <-cw.reverse.Ready():
+				reverse := cw.reverse.Take()
+				animation.SetReverse(reverse)
+				fmt.Printf("[%s] Reverse set to: %v\n", cw.id, reverse)
+			case <-cw.paused.Ready():
+				paused := cw.paused.Take()
+				if paused {
+					animation.Pause()
+				} else {
+					animation.Resume()
+				}
+				fmt.Printf("[%s] Paused set to: %v\n", cw.id, paused)
+			case frame := <-cw.seekChan:
+				animation.SeekTo(frame)
+				cw.currentFrame.Store(int32(animation.GetCurrentFrame()))
+			case <-cw.flip.Ready():
+				animation.SetFlipHorizontal(cw.flip.Take())
+			case <-cw.opacity.Ready():
+				animation.SetOpacity(cw.opacity.Take())
+			case <-cw.tint.Ready():
+				tint := cw.tint.Take()
+				animation.SetTint(tint.R, tint.G, tint.B)
+			case <-cw.state.Ready():
+				state := cw.state.Take()
+				if animation.SetState(state) {
+					cw.idle.stateChanged(sdl.GetTicksNS())
+				} else {
+					fmt.Printf("[%s] Unknown state %q\n", cw.id, state)
+				}
+			case <-cw.speed.Ready():
+				animation.SetSpeed(cw.speed.Take())
+			case <-cw.position.Ready():
+				pos := cw.position.Take()
+				cw.lastPosition.Store(cw.drag.moveTo(window, pos.X, pos.Y))
+			case <-cw.winOpacity.Ready():
+				cw.windowOpacity = cw.winOpacity.Take()
+				sdl.SetWindowOpacity(window, cw.windowOpacity)
+			case <-cw.background.Ready():
+				cw.bgColor = cw.background.Take()
+			case <-cw.onTop.Ready():
+				cw.alwaysOnTop = cw.onTop.Take()
+				cw.sentToBack = false
+				sdl.SetWindowAlwaysOnTop(window, cw.alwaysOnTop)
+			case front := <-cw.stackChan:
+				if front {
+					if cw.sentToBack {
+						cw.sentToBack = false
+						sdl.SetWindowAlwaysOnTop(window, cw.alwaysOnTop)
+					}
+					sdl.RaiseWindow(window)
+				} else if !cw.sentToBack {
+					// SDL can't lower a window, so dropping out of the
+					// always-on-top band puts it below the other characters
+					cw.sentToBack = true
+					sdl.SetWindowAlwaysOnTop(window, false)
+				}
+			case <-cw.gravityOn.Ready():
+				cw.gravity.setEnabled(cw.gravityOn.Take())
+			case <-cw.idleState.Ready():
+				cw.idle.state = cw.idleState.Take()
+			case <-cw.anchor.Ready():
+				cw.scaleAnchor = cw.anchor.Take()
+			case <-cw.behavior.Ready():
+				cw.walk.setEnabled(cw.behavior.Take() == BehaviorWalk)
+			case <-cw.title.Ready():
+				sdl.SetWindowTitle(window, windowTitle(cw.title.Take()))
+			case <-cw.shown.Ready():
+				visible := cw.shown.Take()
+				if visible {
+					sdl.ShowWindow(window)
+				} else {
+					sdl.HideWindow(window)
+				}
+				cw.visible.Store(visible)
+			case reply := <-cw.captureChan:
+				pendingCaptures = append(pendingCaptures, reply)
+			default:
+				break pending
+			}
 		}
 
-		for sdl.PollEvent(&event) {
+		events = pollWindowEvents(windowID, events)
+		for i := range events {
+			event := &events[i]
 			eventType := event.Type()
+			if isInteraction(event, windowID) {
+				cw.idle.interact(sdl.GetTicksNS(), animation)
+			}
 
 			switch eventType {
 			case sdl.EventQuit:
 				return
+			case sdl.EventWindowMoved:
+				if we := event.Window(); we.WindowID == windowID {
+					moved := sdl.Point{X: we.Data1, Y: we.Data2}
+					cw.drag.noteMoved(moved)
+					// One move for snapping and clamping together, made through
+					// the drag tracker so it doesn't count as the user dragging.
+					pos := snappedPosition(window, moved.X, moved.Y)
+					pos = clampedPosition(window, pos.X, pos.Y)
+					if pos != moved {
+						cw.drag.moveTo(window, pos.X, pos.Y)
+					}
+					cw.lastPosition.Store(pos)
+				}
+			case sdl.EventMouseButtonDown:
+				be := event.Button()
+				if be.WindowID != windowID {
+					break
+				}
+				if be.Button == uint8(sdl.ButtonRight) && cw.onContextMenu != nil {
+					var x, y float32
+					sdl.GetGlobalMouseState(&x, &y)
+					cw.onContextMenu(ContextMenuInfo{
+						WindowID:      cw.id,
+						CharacterName: cw.GetCharacterName(),
+						X:             x,
+						Y:             y,
+					})
+				}
 			case sdl.EventKeyDown:
-				key := event.Key().Key
-				if key == sdl.KeycodeEscape {
+				ke := event.Key()
+				if ke.WindowID != windowID {
+					break
+				}
+				switch lookupKeyAction(ke.Key) {
+				case keyActionClose:
 					return
-				} else if key == sdl.KeycodeUp {
+				case keyActionScaleUp:
 					animation.ScaleUp()
-					cw.currentScale.Store(animation.GetScale())
-				} else if key == sdl.KeycodeDown {
+					cw.currentScale.Store(animation.GetTargetScale())
+				case keyActionScaleDown:
 					animation.ScaleDown()
-					cw.currentScale.Store(animation.GetScale())
+					cw.currentScale.Store(animation.GetTargetScale())
 				}
 			}
 		}
+		hitState.releaseLeft()
 
-		animation.Update()
+		if len(manifest.Schedule) > 0 && time.Since(lastScheduleCheck) >= scheduleInterval {
+			lastScheduleCheck = time.Now()
+			if state, ok := manifest.ScheduledState(lastScheduleCheck); ok && state != scheduledState {
+				scheduledState = state
+				if animation.SetState(state) {
+					cw.idle.stateChanged(sdl.GetTicksNS())
+					fmt.Printf("[%s] Schedule switched state to %q\n", cw.id, state)
+				} else {
+					fmt.Printf("[%s] Scheduled state %q not found\n", cw.id, state)
+				}
+			}
+		}
+
+		if pos, moved := cw.gravity.step(window, &cw.drag); moved {
+			cw.lastPosition.Store(pos)
+		}
+		if pos, moved := cw.walk.step(window, &cw.drag, animation); moved {
+			cw.lastPosition.Store(pos)
+		}
+		cw.idle.step(sdl.GetTicksNS(), &cw.drag, animation)
+		cw.publishSettings(animation)
 
-		sdl.SetRenderDrawColor(renderer, 0, 0, 0, 0)
-		sdl.RenderClear(renderer)
-		animation.Render(renderer, window)
-		sdl.RenderPresent(renderer)
+		if !shouldSkipRender(window) || len(pendingCaptures) > 0 {
+			animation.Update()
+			cw.currentFrame.Store(int32(animation.GetCurrentFrame()))
+			publishRenderInfo()
 
-		sdl.DelayNS(16 * 1000000)
+			sdl.SetRenderDrawColor(renderer, cw.bgColor.R, cw.bgColor.G, cw.bgColor.B, cw.bgColor.A)
+			sdl.RenderClear(renderer)
+			animation.Render(renderer, window)
+
+			// Read back before presenting; the buffer is undefined afterwards
+			for _, reply := range pendingCaptures {
+				img, err := readFramebuffer(renderer)
+				reply <- captureResult{img: img, err: err}
+			}
+			pendingCaptures = pendingCaptures[:0]
+
+			sdl.RenderPresent(renderer)
+			statsFrames++
+		}
+
+		if now := sdl.GetTicksNS(); now-statsStart >= statsInterval {
+			stats.FPS = float64(statsFrames) * 1e9 / float64(now-statsStart)
+			stats.FrameCount = animation.FrameCount()
+			cw.stats.Store(stats)
+			statsStart, statsFrames = now, 0
+		}
+
+		if delay := frameDelayNS(sdl.GetTicksNS() - frameStart); delay > 0 {
+			sdl.DelayNS(delay)
+		}
 	}
 }
 
+// createRenderer lets SDL pick the best driver first. VMs and machines
+// without working GPU drivers often fail that, so the software renderer is
+// tried before giving up.
+func (cw *CharacterWindow) createRenderer(window *sdl.Window) (*sdl.Renderer, error) {
+	if renderer := sdl.CreateRenderer(window, ""); renderer != nil {
+		fmt.Printf("[%s] Using %s renderer\n", cw.id, sdl.GetRendererName(renderer))
+		return renderer, nil
+	}
+	defaultErr := sdl.GetError()
+
+	if renderer := sdl.CreateRenderer(window, sdl.SoftwareRenderer); renderer != nil {
+		fmt.Printf("[%s] Default renderer failed (%s), using software renderer\n", cw.id, defaultErr)
+		return renderer, nil
+	}
+	return nil, fmt.Errorf("%s (software fallback: %s)", defaultErr, sdl.GetError())
+}
+
 func (cw *CharacterWindow) Close() {
 	select {
 	case <-cw.closeChan:
@@ -147,18 +677,186 @@ func (cw *CharacterWindow) Close() {
 	}
 }
 
+func (cw *CharacterWindow) isClosing() bool {
+	select {
+	case <-cw.closeChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetScale coalesces rapid calls; the last requested scale is always applied.
 func (cw *CharacterWindow) SetScale(scale float64) {
+	cw.scale.Set(scale)
+}
+
+func (cw *CharacterWindow) SetReverse(reverse bool) {
+	cw.reverse.Set(reverse)
+}
+
+func (cw *CharacterWindow) SetPaused(paused bool) {
+	cw.paused.Set(paused)
+}
+
+func (cw *CharacterWindow) SeekTo(frame int) {
+	select {
+	case cw.seekChan <- frame:
+	default:
+	}
+}
+
+func (cw *CharacterWindow) GetStats() WindowStats {
+	stats, _ := cw.stats.Load().(WindowStats)
+	return stats
+}
+
+func (cw *CharacterWindow) GetCurrentFrame() int {
+	return int(cw.currentFrame.Load())
+}
+
+// GetRenderInfo returns the zero value until the window's frames have loaded.
+func (cw *CharacterWindow) GetRenderInfo() RenderInfo {
+	if info := cw.renderInfo.Load(); info != nil {
+		return *info
+	}
+	return RenderInfo{}
+}
+
+func (cw *CharacterWindow) SetFlipHorizontal(flip bool) {
+	cw.flip.Set(flip)
+}
+
+func (cw *CharacterWindow) SetOpacity(alpha float64) {
+	cw.opacity.Set(alpha)
+}
+
+func (cw *CharacterWindow) SetTint(tint sdl.Color) {
+	cw.tint.Set(tint)
+}
+
+// SetBackground fills the window behind the sprite; an alpha of 0 restores
+// full transparency.
+func (cw *CharacterWindow) SetBackground(r, g, b, a uint8) {
+	cw.background.Set(sdl.Color{R: r, G: g, B: b, A: a})
+}
+
+func (cw *CharacterWindow) SetState(state string) {
+	cw.state.Set(state)
+}
+
+func (cw *CharacterWindow) SetSpeed(speed float64) {
+	cw.speed.Set(speed)
+}
+
+func (cw *CharacterWindow) SetPosition(x, y int32) {
+	cw.position.Set(sdl.Point{X: x, Y: y})
+}
+
+// SetClickThrough takes effect immediately since the hit test callback reads
+// the flag on every mouse press. Clicks on a click-through window no longer
+// drag it or reset its scale, but they are not passed to the window behind.
+func (cw *CharacterWindow) SetClickThrough(enabled bool) {
+	cw.clickThrough.Store(enabled)
+}
+
+// SetLocked only blocks dragging; scale and position changes through the
+// setters still apply.
+func (cw *CharacterWindow) SetLocked(locked bool) {
+	cw.locked.Store(locked)
+}
+
+// SetWindowOpacity fades the whole window, unlike SetOpacity which only
+// changes sprite alpha. The value is clamped to [0,1].
+func (cw *CharacterWindow) SetWindowOpacity(opacity float32) {
+	cw.winOpacity.Set(max(0, min(opacity, 1)))
+}
+
+func (cw *CharacterWindow) SetAlwaysOnTop(onTop bool) {
+	cw.onTop.Set(onTop)
+}
+
+func (cw *CharacterWindow) BringToFront() {
+	cw.setStacking(true)
+}
+
+func (cw *CharacterWindow) SendToBack() {
+	cw.setStacking(false)
+}
+
+func (cw *CharacterWindow) setStacking(front bool) {
 	select {
-	case cw.scaleChan <- scale:
+	case cw.stackChan <- front:
 	default:
 	}
 }
 
+func (cw *CharacterWindow) SetGravity(enabled bool) {
+	cw.gravityOn.Set(enabled)
+}
+
+func (cw *CharacterWindow) SetBehavior(behavior string) {
+	cw.behavior.Set(behavior)
+}
+
+// SetIdleState takes effect the next time the window goes idle; "" restores
+// DefaultIdleState.
+func (cw *CharacterWindow) SetIdleState(state string) {
+	cw.idleState.Set(state)
+}
+
+// SetScaleAnchor overrides the scale anchor of all windows for this one;
+// "" or an unknown anchor follows GetScaleAnchor again.
+func (cw *CharacterWindow) SetScaleAnchor(anchor AnimationEngine.ScaleAnchor) {
+	if !anchor.IsValid() {
+		anchor = ""
+	}
+	cw.anchor.Set(anchor)
+}
+
+func (cw *CharacterWindow) SetVisible(visible bool) {
+	cw.shown.Set(visible)
+}
+
 func (cw *CharacterWindow) GetScale() float64 {
 	if v := cw.currentScale.Load(); v != nil {
 		return v.(float64)
 	}
-	return 0.51
+	return cw.defaultScale
+}
+
+func (cw *CharacterWindow) SetInitialPosition(x, y int32) {
+	cw.lastPosition.Store(sdl.Point{X: x, Y: y})
+}
+
+// SetInitialDisplay must be called before Start. It only applies when no
+// initial position was set; an out-of-range index leaves placement to SDL.
+func (cw *CharacterWindow) SetInitialDisplay(index int) {
+	cw.displayIndex = index
+}
+
+// SetInitialCenter must be called before Start and takes precedence over
+// SetInitialPosition and SetInitialDisplay.
+func (cw *CharacterWindow) SetInitialCenter(x, y int32) {
+	cw.initialCenter = &sdl.Point{X: x, Y: y}
+}
+
+// SetInitialAlwaysOnTop must be called before Start. Unlike SetAlwaysOnTop it
+// picks the flags the window is created with, so a window that starts below
+// others never shows up on top for a frame.
+func (cw *CharacterWindow) SetInitialAlwaysOnTop(onTop bool) {
+	cw.alwaysOnTop = onTop
+}
+
+func (cw *CharacterWindow) GetPosition() (int32, int32, bool) {
+	if pos, ok := cw.lastPosition.Load().(sdl.Point); ok {
+		return pos.X, pos.Y, true
+	}
+	return 0, 0, false
+}
+
+func (cw *CharacterWindow) IsVisible() bool {
+	return cw.visible.Load()
 }
 
 func (cw *CharacterWindow) Wait() {
@@ -169,10 +867,46 @@ func (cw *CharacterWindow) IsRunning() bool {
 	return cw.running.Load()
 }
 
+func (cw *CharacterWindow) Done() <-chan struct{} {
+	return cw.doneChan
+}
+
+// Ready stays open if the window fails to start; select on Done as well.
+func (cw *CharacterWindow) Ready() <-chan struct{} {
+	return cw.readyChan
+}
+
+func (cw *CharacterWindow) GetError() string {
+	if reason := cw.failure.Load(); reason != nil {
+		return *reason
+	}
+	return ""
+}
+
+func (cw *CharacterWindow) IsDone() bool {
+	select {
+	case <-cw.doneChan:
+		return true
+	default:
+		return false
+	}
+}
+
 func (cw *CharacterWindow) GetID() string {
 	return cw.id
 }
 
 func (cw *CharacterWindow) GetCharacterName() string {
-	return cw.characterName
+	return cw.source.Load().characterName
+}
+
+// SetCharacterSource points the window at a renamed character folder. The
+// loaded frames are kept; framesPath is used by the next auto-restart.
+func (cw *CharacterWindow) SetCharacterSource(characterName, framesPath string) {
+	cw.source.Store(&characterSource{characterName: characterName, framesPath: framesPath})
+	cw.title.Set(characterName)
+}
+
+func windowTitle(characterName string) string {
+	return fmt.Sprintf("Boccho - %s", characterName)
 }
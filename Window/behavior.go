@@ -0,0 +1,104 @@
+package Window
+
+/*
+behavior.go - Autonomous behaviors for character windows
+
+The walk behavior moves a window horizontally across the display under it,
+turning around at the display edges and flipping the sprite to face the way
+it walks. Sprites are assumed to face right when not flipped. Dragging the
+window pauses walking (see dragTracker in physics.go).
+
+Functions:
+- SetWalkSpeed: Set walking speed in pixels per second for all windows
+- IsValidBehavior: Check if a behavior name is supported
+- (walkState) setEnabled: Start or stop walking
+- (walkState) step: Advance the walk by the time since the last step
+*/
+
+import (
+	"boccho-ui/AnimationEngine"
+	"sync/atomic"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+const (
+	BehaviorIdle     = "idle"
+	BehaviorWalk     = "walk"
+	defaultWalkSpeed = 60.0 // px/s
+)
+
+var walkSpeed atomic.Value // float64
+
+func init() {
+	walkSpeed.Store(defaultWalkSpeed)
+}
+
+func SetWalkSpeed(pxPerSec float64) {
+	if pxPerSec <= 0 {
+		pxPerSec = defaultWalkSpeed
+	}
+	walkSpeed.Store(pxPerSec)
+}
+
+func IsValidBehavior(behavior string) bool {
+	return behavior == BehaviorIdle || behavior == BehaviorWalk
+}
+
+type walkState struct {
+	enabled  bool
+	dir      int
+	x        float64 // sub-pixel position while walking
+	walking  bool
+	lastStep uint64
+}
+
+func (ws *walkState) setEnabled(enabled bool) {
+	ws.enabled = enabled
+	ws.walking = false
+	if ws.dir == 0 {
+		ws.dir = 1
+	}
+}
+
+func (ws *walkState) step(window *sdl.Window, drag *dragTracker, animation *AnimationEngine.AnimationPlayer) (sdl.Point, bool) {
+	now := sdl.GetTicksNS()
+	dt := float64(now-ws.lastStep) / 1e9
+	ws.lastStep = now
+	if !ws.enabled || dt <= 0 {
+		return sdl.Point{}, false
+	}
+	if drag.dragging(now) {
+		ws.walking = false
+		return sdl.Point{}, false
+	}
+	dt = min(dt, 0.05)
+
+	var x, y, w, h int32
+	if !sdl.GetWindowPosition(window, &x, &y) || !sdl.GetWindowSize(window, &w, &h) {
+		return sdl.Point{}, false
+	}
+	if !ws.walking {
+		ws.walking = true
+		ws.x = float64(x)
+	}
+
+	ws.x += float64(ws.dir) * walkSpeed.Load().(float64) * dt
+	newX := int32(ws.x)
+
+	if d, ok := displayUnder(sdl.Rect{X: x, Y: y, W: w, H: h}, getAllDisplayBounds()); ok {
+		if newX+w >= d.X+d.W {
+			newX, ws.dir = d.X+d.W-w, -1
+			ws.x = float64(newX)
+		} else if newX <= d.X {
+			newX, ws.dir = d.X, 1
+			ws.x = float64(newX)
+		}
+	}
+	animation.SetFlipHorizontal(ws.dir < 0)
+
+	if newX == x {
+		return sdl.Point{}, false
+	}
+	return drag.moveTo(window, newX, y), true
+}
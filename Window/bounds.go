@@ -0,0 +1,61 @@
+package Window
+
+/*
+bounds.go - Keep character windows reachable on the current displays
+
+A window counts as reachable while a strip of at least minVisiblePixels
+overlaps some display on both axes. Windows dragged further off-screen, or
+restored onto a monitor that is no longer connected, are moved the shortest
+distance that makes them reachable again.
+
+Functions:
+- clampedPosition: Where a window must go to stay on the connected displays, without moving it
+- clampPosition: Compute the nearest reachable position of a rectangle against displays
+- clampAxis: Clamp one coordinate so a span keeps a minimum overlap with a range
+*/
+
+import "github.com/jupiterrider/purego-sdl3/sdl"
+
+const minVisiblePixels = 48
+
+func clampedPosition(window *sdl.Window, x, y int32) sdl.Point {
+	var w, h int32
+	if !sdl.GetWindowSize(window, &w, &h) {
+		return sdl.Point{X: x, Y: y}
+	}
+
+	return clampPosition(sdl.Rect{X: x, Y: y, W: w, H: h}, getAllDisplayBounds(), minVisiblePixels)
+}
+
+func clampPosition(win sdl.Rect, displays []sdl.Rect, minVisible int32) sdl.Point {
+	current := sdl.Point{X: win.X, Y: win.Y}
+	if len(displays) == 0 {
+		return current
+	}
+
+	minW, minH := min(minVisible, win.W), min(minVisible, win.H)
+
+	best := current
+	bestDist := int64(-1)
+	for _, d := range displays {
+		candidate := sdl.Point{
+			X: clampAxis(win.X, win.W, d.X, d.W, minW),
+			Y: clampAxis(win.Y, win.H, d.Y, d.H, minH),
+		}
+		if candidate == current {
+			return current
+		}
+
+		dx, dy := int64(candidate.X-win.X), int64(candidate.Y-win.Y)
+		if dist := dx*dx + dy*dy; bestDist < 0 || dist < bestDist {
+			best, bestDist = candidate, dist
+		}
+	}
+	return best
+}
+
+func clampAxis(pos, size, rangeStart, rangeSize, minOverlap int32) int32 {
+	lo := rangeStart - size + minOverlap
+	hi := rangeStart + rangeSize - minOverlap
+	return max(lo, min(pos, hi))
+}
@@ -0,0 +1,36 @@
+package Window
+
+import (
+	"testing"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+func TestClampPosition(t *testing.T) {
+	primary := sdl.Rect{X: 0, Y: 0, W: 1920, H: 1080}
+	secondary := sdl.Rect{X: 1920, Y: 0, W: 1280, H: 1024}
+	both := []sdl.Rect{primary, secondary}
+
+	tests := []struct {
+		name     string
+		win      sdl.Rect
+		displays []sdl.Rect
+		want     sdl.Point
+	}{
+		{"on screen", sdl.Rect{X: 100, Y: 100, W: 200, H: 200}, both, sdl.Point{X: 100, Y: 100}},
+		{"on second display", sdl.Rect{X: 2500, Y: 500, W: 200, H: 200}, both, sdl.Point{X: 2500, Y: 500}},
+		{"partly off screen", sdl.Rect{X: -100, Y: 100, W: 200, H: 200}, both, sdl.Point{X: -100, Y: 100}},
+		{"off the left edge", sdl.Rect{X: -500, Y: 100, W: 200, H: 200}, both, sdl.Point{X: -152, Y: 100}},
+		{"off the right edge", sdl.Rect{X: 5000, Y: 100, W: 200, H: 200}, both, sdl.Point{X: 3152, Y: 100}},
+		{"below the primary", sdl.Rect{X: 100, Y: 2000, W: 200, H: 200}, both, sdl.Point{X: 100, Y: 1032}},
+		{"below the shorter display", sdl.Rect{X: 2500, Y: 1050, W: 200, H: 200}, both, sdl.Point{X: 2500, Y: 976}},
+		{"unplugged monitor", sdl.Rect{X: -1920, Y: 200, W: 200, H: 200}, []sdl.Rect{primary}, sdl.Point{X: -152, Y: 200}},
+		{"smaller than the strip", sdl.Rect{X: -100, Y: -100, W: 20, H: 20}, both, sdl.Point{X: 0, Y: 0}},
+		{"no displays", sdl.Rect{X: -5000, Y: -5000, W: 200, H: 200}, nil, sdl.Point{X: -5000, Y: -5000}},
+	}
+	for _, tt := range tests {
+		if got := clampPosition(tt.win, tt.displays, minVisiblePixels); got != tt.want {
+			t.Errorf("%s: clampPosition(%v) = %v, want %v", tt.name, tt.win, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package Window
+
+/*
+capture.go - Framebuffer capture for character windows
+
+Only the window's own thread may touch its renderer, so a capture is queued
+through a channel and fulfilled right after the next frame is drawn, before it
+is presented. The pixels are copied out as straight-alpha RGBA so the
+transparent background survives in the result.
+
+Functions:
+- (CharacterWindow) Capture: Request a copy of the next rendered frame
+- readFramebuffer: Copy the renderer's current frame into an image
+*/
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"time"
+	"unsafe"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+const captureTimeout = 2 * time.Second
+
+type captureResult struct {
+	img *image.NRGBA
+	err error
+}
+
+func (cw *CharacterWindow) Capture() (*image.NRGBA, error) {
+	reply := make(chan captureResult, 1)
+
+	select {
+	case cw.captureChan <- reply:
+	case <-cw.doneChan:
+		return nil, errors.New("window is closed")
+	case <-time.After(captureTimeout):
+		return nil, errors.New("window is busy")
+	}
+
+	select {
+	case res := <-reply:
+		return res.img, res.err
+	case <-cw.doneChan:
+		return nil, errors.New("window closed before capture")
+	case <-time.After(captureTimeout):
+		return nil, errors.New("timed out waiting for capture")
+	}
+}
+
+func readFramebuffer(renderer *sdl.Renderer) (*image.NRGBA, error) {
+	surface := sdl.RenderReadPixels(renderer, nil)
+	if surface == nil {
+		return nil, fmt.Errorf("failed to read pixels: %s", sdl.GetError())
+	}
+	defer sdl.DestroySurface(surface)
+
+	rgba := sdl.ConvertSurface(surface, sdl.PixelFormatRGBA32)
+	if rgba == nil {
+		return nil, fmt.Errorf("failed to convert pixels: %s", sdl.GetError())
+	}
+	defer sdl.DestroySurface(rgba)
+
+	img := image.NewNRGBA(image.Rect(0, 0, int(rgba.W), int(rgba.H)))
+	pixels := unsafe.Slice((*uint8)(rgba.Pixels), int(rgba.Pitch)*int(rgba.H))
+	rowBytes := int(rgba.W) * 4
+	for y := 0; y < int(rgba.H); y++ {
+		copy(img.Pix[y*img.Stride:y*img.Stride+rowBytes], pixels[y*int(rgba.Pitch):])
+	}
+	return img, nil
+}
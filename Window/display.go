@@ -0,0 +1,136 @@
+package Window
+
+/*
+display.go - Display geometry queries not covered by purego-sdl3
+
+purego-sdl3 leaves SDL_GetDisplayBounds unbound, so it is registered here
+against the same SDL3 shared library the sdl package already loaded.
+
+Functions:
+- GetDisplays: List connected displays with their names and bounds
+- getDisplayBounds: Get the desktop area of a display in global coordinates
+- getAllDisplayBounds: Get the bounds of every connected display
+- loadDisplayFuncs: Resolve display functions from the SDL3 library once
+- GetCursorPosition: Get the global mouse position in screen coordinates
+- displayUnder: Get the display containing the center of a rectangle
+- centerOnPoint: Center a rectangle on a point, kept inside the display under it
+- centerInDisplay: Get the position that centers a window on a display by index
+*/
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ebitengine/purego"
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+type DisplayInfo struct {
+	Index  int    `json:"index"`
+	Name   string `json:"name"`
+	X      int32  `json:"x"`
+	Y      int32  `json:"y"`
+	Width  int32  `json:"width"`
+	Height int32  `json:"height"`
+}
+
+var (
+	displayFuncsOnce    sync.Once
+	displayFuncsErr     error
+	sdlGetDisplayBounds func(sdl.DisplayID, *sdl.Rect) bool
+)
+
+func loadDisplayFuncs() error {
+	displayFuncsOnce.Do(func() {
+		lib, err := loadSDLLibrary()
+		if err != nil {
+			displayFuncsErr = fmt.Errorf("failed to load SDL3 library: %w", err)
+			return
+		}
+		purego.RegisterLibFunc(&sdlGetDisplayBounds, lib, "SDL_GetDisplayBounds")
+	})
+	return displayFuncsErr
+}
+
+func getDisplayBounds(displayID sdl.DisplayID) (sdl.Rect, bool) {
+	if err := loadDisplayFuncs(); err != nil {
+		fmt.Printf("Warning: Display bounds unavailable: %v\n", err)
+		return sdl.Rect{}, false
+	}
+
+	var rect sdl.Rect
+	if !sdlGetDisplayBounds(displayID, &rect) {
+		return sdl.Rect{}, false
+	}
+	return rect, true
+}
+
+func getAllDisplayBounds() []sdl.Rect {
+	var bounds []sdl.Rect
+	for _, displayID := range sdl.GetDisplays() {
+		if rect, ok := getDisplayBounds(displayID); ok {
+			bounds = append(bounds, rect)
+		}
+	}
+	return bounds
+}
+
+func GetDisplays() []DisplayInfo {
+	displays := []DisplayInfo{}
+	for i, displayID := range sdl.GetDisplays() {
+		rect, ok := getDisplayBounds(displayID)
+		if !ok {
+			continue
+		}
+		displays = append(displays, DisplayInfo{
+			Index:  i,
+			Name:   sdl.GetDisplayName(displayID),
+			X:      rect.X,
+			Y:      rect.Y,
+			Width:  rect.W,
+			Height: rect.H,
+		})
+	}
+	return displays
+}
+
+func centerInDisplay(index int, w, h int32) (sdl.Point, bool) {
+	displays := sdl.GetDisplays()
+	if index < 0 || index >= len(displays) {
+		return sdl.Point{}, false
+	}
+
+	rect, ok := getDisplayBounds(displays[index])
+	if !ok {
+		return sdl.Point{}, false
+	}
+	return sdl.Point{X: rect.X + (rect.W-w)/2, Y: rect.Y + (rect.H-h)/2}, true
+}
+
+func GetCursorPosition() (int32, int32) {
+	var x, y float32
+	sdl.GetGlobalMouseState(&x, &y)
+	return int32(x), int32(y)
+}
+
+func centerOnPoint(center sdl.Point, w, h int32, displays []sdl.Rect) sdl.Point {
+	pos := sdl.Point{X: center.X - w/2, Y: center.Y - h/2}
+	for _, d := range displays {
+		if center.X >= d.X && center.X < d.X+d.W && center.Y >= d.Y && center.Y < d.Y+d.H {
+			pos.X = max(d.X, min(pos.X, d.X+d.W-w))
+			pos.Y = max(d.Y, min(pos.Y, d.Y+d.H-h))
+			break
+		}
+	}
+	return pos
+}
+
+func displayUnder(win sdl.Rect, displays []sdl.Rect) (sdl.Rect, bool) {
+	cx, cy := win.X+win.W/2, win.Y+win.H/2
+	for _, d := range displays {
+		if cx >= d.X && cx < d.X+d.W && cy >= d.Y && cy < d.Y+d.H {
+			return d, true
+		}
+	}
+	return sdl.Rect{}, false
+}
@@ -0,0 +1,101 @@
+package Window
+
+/*
+events.go - Route SDL events to the window they belong to
+
+SDL has a single event queue for the whole process and every character
+window's thread polls it, so a thread regularly pulls events meant for
+another window. Instead of dropping those, whatever a thread polls is moved
+into per-window queues keyed by window ID, and each thread then handles only
+its own queue. Events without a window, such as quit or display changes, are
+queued for every window.
+
+Text and drop events point at strings SDL frees on the next poll, so they
+are not queued; no window handles them.
+
+Functions:
+- registerEventQueue: Start queueing events for a window
+- unregisterEventQueue: Stop queueing events for a window and drop its queue
+- pollWindowEvents: Pump SDL's queue and take the queued events of one window
+- eventWindowID: Window an event belongs to, or 0 for events without one
+*/
+
+import (
+	"sync"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+var (
+	eventMu     sync.Mutex
+	eventQueues = make(map[sdl.WindowID][]sdl.Event)
+)
+
+func registerEventQueue(id sdl.WindowID) {
+	eventMu.Lock()
+	eventQueues[id] = nil
+	eventMu.Unlock()
+}
+
+func unregisterEventQueue(id sdl.WindowID) {
+	eventMu.Lock()
+	delete(eventQueues, id)
+	eventMu.Unlock()
+}
+
+// pollWindowEvents returns events with the queued events of id appended to
+// events[:0], so the caller can pass the previous result back in to reuse it.
+// SDL is polled without holding eventMu, since pumping can run callbacks.
+func pollWindowEvents(id sdl.WindowID, events []sdl.Event) []sdl.Event {
+	var polled []sdl.Event
+	var event sdl.Event
+	for sdl.PollEvent(&event) {
+		switch event.Type() {
+		case sdl.EventTextEditing, sdl.EventTextInput, sdl.EventDropFile, sdl.EventDropText,
+			sdl.EventDropBegin, sdl.EventDropComplete, sdl.EventDropPosition:
+			continue
+		}
+		polled = append(polled, event)
+	}
+
+	eventMu.Lock()
+	defer eventMu.Unlock()
+
+	for i := range polled {
+		if target := eventWindowID(&polled[i]); target != 0 {
+			// Events of windows that already closed are dropped.
+			if queue, ok := eventQueues[target]; ok {
+				eventQueues[target] = append(queue, polled[i])
+			}
+			continue
+		}
+		for wid, queue := range eventQueues {
+			eventQueues[wid] = append(queue, polled[i])
+		}
+	}
+
+	queue, ok := eventQueues[id]
+	events = append(events[:0], queue...)
+	if ok {
+		eventQueues[id] = queue[:0]
+	}
+	return events
+}
+
+func eventWindowID(event *sdl.Event) sdl.WindowID {
+	switch eventType := event.Type(); eventType {
+	case sdl.EventKeyDown, sdl.EventKeyUp:
+		return event.Key().WindowID
+	case sdl.EventMouseMotion:
+		return event.Motion().WindowID
+	case sdl.EventMouseButtonDown, sdl.EventMouseButtonUp:
+		return event.Button().WindowID
+	case sdl.EventMouseWheel:
+		return event.Wheel().WindowID
+	default:
+		if eventType >= sdl.EventWindowFirst && eventType <= sdl.EventWindowLast {
+			return event.Window().WindowID
+		}
+	}
+	return 0
+}
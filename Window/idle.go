@@ -0,0 +1,94 @@
+package Window
+
+/*
+idle.go - Switch characters to an idle animation when left alone
+
+After the idle timeout passes without the mouse or keyboard touching a
+window (clicks, hovering, wheel, keys or dragging it), the window switches
+to its idle state, "sleep" unless SetIdleState chose another. The next
+interaction switches back to the state that was playing before. Characters
+without the idle state keep playing as they are. A timeout of 0 disables
+idling for all windows.
+
+Functions:
+- SetIdleTimeout: Set the idle timeout in seconds for all windows (0 disables)
+- isInteraction: Check if an event is the user interacting with a window
+- (idleTracker) interact: Record an interaction, waking the character if it was idle
+- (idleTracker) stateChanged: Forget the idle state after another state was chosen
+- (idleTracker) step: Switch to the idle state once the timeout has passed
+*/
+
+import (
+	"boccho-ui/AnimationEngine"
+	"sync/atomic"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+const DefaultIdleState = "sleep"
+
+var idleTimeoutNS atomic.Uint64
+
+func SetIdleTimeout(seconds int) {
+	idleTimeoutNS.Store(uint64(max(seconds, 0)) * 1e9)
+}
+
+func isInteraction(event *sdl.Event, windowID sdl.WindowID) bool {
+	switch event.Type() {
+	case sdl.EventMouseButtonDown, sdl.EventMouseButtonUp:
+		return event.Button().WindowID == windowID
+	case sdl.EventMouseMotion:
+		return event.Motion().WindowID == windowID
+	case sdl.EventMouseWheel:
+		return event.Wheel().WindowID == windowID
+	case sdl.EventKeyDown, sdl.EventKeyUp:
+		return event.Key().WindowID == windowID
+	}
+	return false
+}
+
+// idleTracker is only used on the window's render thread.
+type idleTracker struct {
+	state           string // "" means DefaultIdleState
+	lastInteraction uint64
+	idle            bool
+	resumeState     string
+}
+
+func (it *idleTracker) interact(now uint64, animation *AnimationEngine.AnimationPlayer) {
+	it.lastInteraction = now
+	if it.idle {
+		it.idle = false
+		animation.SetState(it.resumeState)
+	}
+}
+
+func (it *idleTracker) stateChanged(now uint64) {
+	it.idle = false
+	it.lastInteraction = now
+}
+
+func (it *idleTracker) step(now uint64, drag *dragTracker, animation *AnimationEngine.AnimationPlayer) {
+	if drag.dragging(now) {
+		it.interact(now, animation)
+		return
+	}
+
+	timeout := idleTimeoutNS.Load()
+	if timeout == 0 || it.idle || now-it.lastInteraction < timeout {
+		return
+	}
+
+	state := it.state
+	if state == "" {
+		state = DefaultIdleState
+	}
+	previous := animation.GetState()
+	if state == previous || !animation.SetState(state) {
+		// Checked again after the next interaction rather than every frame.
+		it.lastInteraction = now
+		return
+	}
+	it.idle = true
+	it.resumeState = previous
+}
@@ -0,0 +1,59 @@
+package Window
+
+/*
+input.go - Mouse gesture detection shared by all character windows
+
+SDL's own click counting follows the OS setting, so double-clicks are detected
+here against a configurable interval instead. A second left press only counts
+when it lands close to the first.
+
+Functions:
+- SetDoubleClickInterval: Set the maximum delay between double-click presses
+- (clickTracker) press: Record a left press and report whether it completes a double-click
+*/
+
+import (
+	"sync/atomic"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+const (
+	defaultDoubleClickMs = 300
+	doubleClickSlop      = 4 // max distance in pixels between the two presses
+)
+
+var doubleClickMs atomic.Int64
+
+func init() {
+	doubleClickMs.Store(defaultDoubleClickMs)
+}
+
+func SetDoubleClickInterval(ms int) {
+	if ms <= 0 {
+		ms = defaultDoubleClickMs
+	}
+	doubleClickMs.Store(int64(ms))
+}
+
+type clickTracker struct {
+	lastTicks uint64
+	lastX     float32
+	lastY     float32
+}
+
+func (t *clickTracker) press(x, y float32) bool {
+	now := sdl.GetTicks()
+	dx, dy := x-t.lastX, y-t.lastY
+	isDouble := t.lastTicks != 0 &&
+		now-t.lastTicks <= uint64(doubleClickMs.Load()) &&
+		dx*dx+dy*dy <= doubleClickSlop*doubleClickSlop
+
+	if isDouble {
+		// Reset so a triple click doesn't count as two double-clicks
+		t.lastTicks = 0
+	} else {
+		t.lastTicks, t.lastX, t.lastY = now, x, y
+	}
+	return isDouble
+}
@@ -0,0 +1,74 @@
+package Window
+
+/*
+keybindings.go - Keyboard shortcuts shared by all character windows
+
+Bindings are given as SDL key names (e.g. "Escape", "Up", "Page Up"). An empty
+name disables the action, which lets users turn off close-on-Escape.
+
+Functions:
+- SetKeyBindings: Resolve key names and apply them to all windows
+- lookupKeyAction: Map a pressed key to its bound action
+*/
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+type keyAction int
+
+const (
+	keyActionNone keyAction = iota
+	keyActionClose
+	keyActionScaleUp
+	keyActionScaleDown
+)
+
+type KeyBindings struct {
+	Close     string
+	ScaleUp   string
+	ScaleDown string
+}
+
+var keyActions atomic.Value // map[sdl.Keycode]keyAction
+
+func init() {
+	keyActions.Store(map[sdl.Keycode]keyAction{
+		sdl.KeycodeEscape: keyActionClose,
+		sdl.KeycodeUp:     keyActionScaleUp,
+		sdl.KeycodeDown:   keyActionScaleDown,
+	})
+}
+
+// SetKeyBindings needs SDL to be initialized to resolve key names. Unknown
+// names are reported and leave that action unbound.
+func SetKeyBindings(bindings KeyBindings) {
+	actions := make(map[sdl.Keycode]keyAction)
+	for _, binding := range []struct {
+		name   string
+		action keyAction
+	}{
+		{bindings.Close, keyActionClose},
+		{bindings.ScaleUp, keyActionScaleUp},
+		{bindings.ScaleDown, keyActionScaleDown},
+	} {
+		name := binding.name
+		if name == "" {
+			continue
+		}
+		key := sdl.GetKeyFromName(name)
+		if key == sdl.KeycodeUnknown {
+			fmt.Printf("Warning: Unknown key name %q in key bindings\n", name)
+			continue
+		}
+		actions[key] = binding.action
+	}
+	keyActions.Store(actions)
+}
+
+func lookupKeyAction(key sdl.Keycode) keyAction {
+	return keyActions.Load().(map[sdl.Keycode]keyAction)[key]
+}
@@ -0,0 +1,46 @@
+package Window
+
+/*
+latest.go - Coalesce settings sent to the render thread
+
+A buffered channel drops values once it is full, so a burst of updates (a
+slider being dragged) can lose the last one. latestValue keeps only the most
+recent value and a one-slot signal; the render thread takes whatever is
+newest when it wakes up. Per-window settings where only the final value
+matters should use this instead of a buffered channel.
+
+Functions:
+- newLatestValue: Create an empty latestValue
+- (latestValue) Set: Replace the pending value and wake the render thread
+- (latestValue) Ready: Channel that receives when a value is pending
+- (latestValue) Take: Read the most recent value
+*/
+
+import "sync/atomic"
+
+type latestValue[T any] struct {
+	value atomic.Pointer[T]
+	ready chan struct{}
+}
+
+func newLatestValue[T any]() *latestValue[T] {
+	return &latestValue[T]{ready: make(chan struct{}, 1)}
+}
+
+// Set never blocks. A signal already pending covers the new value too.
+func (l *latestValue[T]) Set(v T) {
+	l.value.Store(&v)
+	select {
+	case l.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (l *latestValue[T]) Ready() <-chan struct{} {
+	return l.ready
+}
+
+// Take must only be called after receiving from Ready, so a value is set.
+func (l *latestValue[T]) Take() T {
+	return *l.value.Load()
+}
@@ -0,0 +1,86 @@
+package Window
+
+/*
+pacing.go - Frame pacing shared by all character windows
+
+Each window aims for one iteration per frame interval, derived from the
+target FPS. The time spent handling events and rendering is subtracted from
+the sleep, so slow frames don't stretch the interval further.
+
+Power-saver mode lowers the render rate of every window and skips rendering
+windows that are hidden, minimized or occluded.
+
+Functions:
+- SetPowerSaver: Enable or disable power-saver pacing for all windows
+- IsPowerSaver: Check if power-saver pacing is enabled
+- SetTargetFPS / GetTargetFPS: Choose the render rate of all windows
+- frameIntervalNS: Get the current time between frames in nanoseconds
+- frameDelayNS: Get how long to sleep after an iteration that took elapsed ns
+- shouldSkipRender: Check if a window can skip rendering this frame
+*/
+
+import (
+	"sync/atomic"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+const (
+	DefaultTargetFPS        = 60
+	MaxTargetFPS            = 240
+	powerSaverFrameInterval = 33 * 1000000 // ~30fps, unless the target is lower
+)
+
+var (
+	powerSaver atomic.Bool
+	targetFPS  atomic.Int32
+)
+
+func init() {
+	targetFPS.Store(DefaultTargetFPS)
+}
+
+func SetPowerSaver(enabled bool) {
+	powerSaver.Store(enabled)
+}
+
+func IsPowerSaver() bool {
+	return powerSaver.Load()
+}
+
+// SetTargetFPS uses DefaultTargetFPS for fps <= 0 and caps it at MaxTargetFPS.
+func SetTargetFPS(fps int) {
+	if fps <= 0 {
+		fps = DefaultTargetFPS
+	}
+	targetFPS.Store(int32(min(fps, MaxTargetFPS)))
+}
+
+func GetTargetFPS() int {
+	return int(targetFPS.Load())
+}
+
+func frameIntervalNS() uint64 {
+	interval := uint64(1e9) / uint64(targetFPS.Load())
+	if powerSaver.Load() {
+		return max(interval, powerSaverFrameInterval)
+	}
+	return interval
+}
+
+// frameDelayNS returns 0 when the iteration already used up the interval;
+// the next frame then starts right away rather than trying to catch up.
+func frameDelayNS(elapsed uint64) uint64 {
+	interval := frameIntervalNS()
+	if elapsed >= interval {
+		return 0
+	}
+	return interval - elapsed
+}
+
+func shouldSkipRender(window *sdl.Window) bool {
+	if !powerSaver.Load() {
+		return false
+	}
+	return sdl.GetWindowFlags(window)&(sdl.WindowHidden|sdl.WindowMinimized|sdl.WindowOccluded) != 0
+}
@@ -0,0 +1,87 @@
+package Window
+
+import (
+	"testing"
+	"time"
+)
+
+// withPacing sets the shared pacing state for one test and restores it after.
+func withPacing(tb testing.TB, fps int, saver bool) {
+	tb.Helper()
+	prevFPS, prevSaver := GetTargetFPS(), IsPowerSaver()
+	tb.Cleanup(func() {
+		SetTargetFPS(prevFPS)
+		SetPowerSaver(prevSaver)
+	})
+	SetTargetFPS(fps)
+	SetPowerSaver(saver)
+}
+
+func TestFrameDelayNS(t *testing.T) {
+	tests := []struct {
+		fps     int
+		saver   bool
+		elapsed uint64
+		want    uint64
+	}{
+		{60, false, 0, 16666666},
+		{60, false, 6666666, 10000000},
+		{60, false, 20000000, 0},
+		{60, true, 3000000, 30000000},
+		{20, true, 0, 50000000},
+		{1000, false, 0, uint64(1e9) / MaxTargetFPS},
+		{0, false, 0, uint64(1e9) / DefaultTargetFPS},
+	}
+	for _, tt := range tests {
+		withPacing(t, tt.fps, tt.saver)
+		if got := frameDelayNS(tt.elapsed); got != tt.want {
+			t.Errorf("fps %d, power saver %v, elapsed %d: delay %d, want %d",
+				tt.fps, tt.saver, tt.elapsed, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkFrameDelayNS(b *testing.B) {
+	withPacing(b, DefaultTargetFPS, false)
+	for i := 0; i < b.N; i++ {
+		frameDelayNS(uint64(i % 20000000))
+	}
+}
+
+// BenchmarkIdleLoop runs the pacing of an idle window, which renders in
+// about a millisecond, and reports how many frames it draws per second and
+// how much of the time it is awake. The old loop slept a fixed 16ms after
+// the work, which matches the 60fps case; a lower target or power-saver
+// mode halves the time awake.
+func BenchmarkIdleLoop(b *testing.B) {
+	const work = time.Millisecond
+
+	for _, mode := range []struct {
+		name  string
+		fps   int
+		saver bool
+	}{
+		{"60fps", 60, false},
+		{"30fps", 30, false},
+		{"power saver", 60, true},
+	} {
+		b.Run(mode.name, func(b *testing.B) {
+			withPacing(b, mode.fps, mode.saver)
+
+			var awake time.Duration
+			start := time.Now()
+			for i := 0; i < b.N; i++ {
+				frameStart := time.Now()
+				for time.Since(frameStart) < work {
+				}
+				elapsed := time.Since(frameStart)
+				awake += elapsed
+				time.Sleep(time.Duration(frameDelayNS(uint64(elapsed))))
+			}
+			total := time.Since(start)
+
+			b.ReportMetric(float64(b.N)/total.Seconds(), "frames/s")
+			b.ReportMetric(100*awake.Seconds()/total.Seconds(), "%awake")
+		})
+	}
+}
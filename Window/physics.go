@@ -0,0 +1,130 @@
+package Window
+
+/*
+physics.go - Gravity and automatic movement of character windows
+
+With gravity enabled a window falls once the user lets go of it and rests on
+the bottom edge of the display under its center. Moves we did not make
+ourselves are treated as the user dragging: they stop any automatic movement
+for dragGraceNS so the window doesn't slip out of the cursor.
+
+Functions:
+- (dragTracker) noteMoved: Record a window move event, reporting if the user made it
+- (dragTracker) dragging: Check if the user moved the window within the grace period
+- (dragTracker) moveTo: Move the window without it counting as a user drag
+- (gravityState) setEnabled: Turn gravity on or off, dropping any fall speed
+- (gravityState) step: Advance the fall by the time since the last step
+- floorFor: Get the floor y coordinate for a window rectangle
+*/
+
+import "github.com/jupiterrider/purego-sdl3/sdl"
+
+const (
+	gravityAccel = 2400.0 // px/s^2
+	maxFallSpeed = 3000.0 // px/s
+	dragGraceNS  = 150_000_000
+)
+
+type dragTracker struct {
+	expected    sdl.Point
+	hasExpected bool
+	lastDrag    uint64
+}
+
+func (d *dragTracker) noteMoved(pos sdl.Point) bool {
+	if d.hasExpected && pos == d.expected {
+		return false
+	}
+	d.lastDrag = sdl.GetTicksNS()
+	return true
+}
+
+func (d *dragTracker) dragging(now uint64) bool {
+	return now-d.lastDrag < dragGraceNS
+}
+
+func (d *dragTracker) moveTo(window *sdl.Window, x, y int32) sdl.Point {
+	pos := sdl.Point{X: x, Y: y}
+	d.expected, d.hasExpected = pos, true
+	sdl.SetWindowPosition(window, x, y)
+	return pos
+}
+
+type gravityState struct {
+	enabled  bool
+	vy       float64
+	y        float64 // sub-pixel position while falling
+	falling  bool
+	lastStep uint64
+}
+
+func (g *gravityState) setEnabled(enabled bool) {
+	g.enabled = enabled
+	g.vy = 0
+	g.falling = false
+}
+
+func (g *gravityState) step(window *sdl.Window, drag *dragTracker) (sdl.Point, bool) {
+	now := sdl.GetTicksNS()
+	dt := float64(now-g.lastStep) / 1e9
+	g.lastStep = now
+	if !g.enabled || dt <= 0 {
+		return sdl.Point{}, false
+	}
+	if drag.dragging(now) {
+		// Grabbing the window cancels the fall
+		g.vy = 0
+		g.falling = false
+		return sdl.Point{}, false
+	}
+	// Long stalls (e.g. a modal drag loop) shouldn't teleport the window
+	dt = min(dt, 0.05)
+
+	var x, y, w, h int32
+	if !sdl.GetWindowPosition(window, &x, &y) || !sdl.GetWindowSize(window, &w, &h) {
+		return sdl.Point{}, false
+	}
+
+	floor, ok := floorFor(sdl.Rect{X: x, Y: y, W: w, H: h}, getAllDisplayBounds())
+	if !ok || y+h >= floor {
+		g.falling = false
+		g.vy = 0
+		if ok && y+h > floor {
+			return drag.moveTo(window, x, floor-h), true
+		}
+		return sdl.Point{}, false
+	}
+
+	if !g.falling {
+		g.falling = true
+		g.y = float64(y)
+	}
+	g.vy = min(g.vy+gravityAccel*dt, maxFallSpeed)
+	g.y += g.vy * dt
+
+	newY := int32(g.y)
+	if newY+h >= floor {
+		newY = floor - h
+		g.falling = false
+		g.vy = 0
+	}
+	return drag.moveTo(window, x, newY), true
+}
+
+// floorFor uses the display under the window's horizontal center; among
+// stacked displays it picks the one the window is currently in or above.
+func floorFor(win sdl.Rect, displays []sdl.Rect) (int32, bool) {
+	cx, cy := win.X+win.W/2, win.Y+win.H/2
+
+	var floor int32
+	found := false
+	for _, d := range displays {
+		if cx < d.X || cx >= d.X+d.W || cy >= d.Y+d.H {
+			continue
+		}
+		if bottom := d.Y + d.H; !found || bottom < floor {
+			floor, found = bottom, true
+		}
+	}
+	return floor, found
+}
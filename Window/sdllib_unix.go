@@ -0,0 +1,19 @@
+//go:build !windows
+
+package Window
+
+import (
+	"runtime"
+
+	"github.com/ebitengine/purego"
+)
+
+// loadSDLLibrary returns a handle to the SDL3 library; dlopen reuses the
+// copy already mapped by the sdl package.
+func loadSDLLibrary() (uintptr, error) {
+	filename := "libSDL3.so.0"
+	if runtime.GOOS == "darwin" {
+		filename = "libSDL3.dylib"
+	}
+	return purego.Dlopen(filename, purego.RTLD_LAZY)
+}
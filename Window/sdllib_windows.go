@@ -0,0 +1,10 @@
+package Window
+
+import "syscall"
+
+// loadSDLLibrary returns a handle to the SDL3 library; LoadLibrary reuses the
+// copy already mapped by the sdl package.
+func loadSDLLibrary() (uintptr, error) {
+	handle, err := syscall.LoadLibrary("SDL3.dll")
+	return uintptr(handle), err
+}
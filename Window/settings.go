@@ -0,0 +1,122 @@
+package Window
+
+/*
+settings.go - Per-window settings saved with layouts and sessions
+
+Most settings are applied on the window's render thread, so the thread
+publishes a copy whenever one of them changes and GetSettings can read it
+from any goroutine. The state of an idle character is saved as the state it
+wakes up to, not its idle state. Colors are saved as #rrggbb strings so the
+layouts file stays readable; an empty Tint, from layouts saved before tints
+were, leaves the sprite untinted.
+
+Functions:
+- (CharacterWindow) GetSettings: Read the window's current settings
+- (CharacterWindow) ApplySettings: Queue every setting of a saved snapshot
+- (CharacterWindow) publishSettings: Publish the render thread's settings if they changed
+- hexColor: Format a color as #rrggbb
+*/
+
+import (
+	"fmt"
+
+	"boccho-ui/AnimationEngine"
+)
+
+type WindowSettings struct {
+	State           string
+	Flip            bool
+	Opacity         float64
+	Speed           float64
+	WindowOpacity   float32
+	AlwaysOnTop     bool
+	Gravity         bool
+	Behavior        string
+	Locked          bool
+	ClickThrough    bool
+	Tint            string // "#rrggbb"
+	Reverse         bool
+	Paused          bool
+	Background      string // "#rrggbb"; BackgroundAlpha 0 keeps the window transparent
+	BackgroundAlpha uint8
+	ScaleAnchor     string // "" follows the scale anchor of all windows
+	IdleState       string // "" means DefaultIdleState
+}
+
+// GetSettings reports ok=false until the window's frames have loaded.
+func (cw *CharacterWindow) GetSettings() (WindowSettings, bool) {
+	published := cw.settings.Load()
+	if published == nil {
+		return WindowSettings{}, false
+	}
+	settings := *published
+	settings.Locked = cw.locked.Load()
+	settings.ClickThrough = cw.clickThrough.Load()
+	return settings, true
+}
+
+// ApplySettings may be called before Start. An empty State keeps the
+// character's default state.
+func (cw *CharacterWindow) ApplySettings(settings WindowSettings) {
+	if settings.State != "" {
+		cw.SetState(settings.State)
+	}
+	cw.SetFlipHorizontal(settings.Flip)
+	cw.SetOpacity(settings.Opacity)
+	cw.SetSpeed(settings.Speed)
+	cw.SetWindowOpacity(settings.WindowOpacity)
+	cw.SetAlwaysOnTop(settings.AlwaysOnTop)
+	cw.SetGravity(settings.Gravity)
+	if IsValidBehavior(settings.Behavior) {
+		cw.SetBehavior(settings.Behavior)
+	}
+	cw.SetLocked(settings.Locked)
+	cw.SetClickThrough(settings.ClickThrough)
+	if tint, err := ParseHexColor(settings.Tint); err == nil {
+		cw.SetTint(tint)
+	}
+	cw.SetReverse(settings.Reverse)
+	cw.SetPaused(settings.Paused)
+	background, err := ParseHexColor(settings.Background)
+	if err != nil {
+		settings.BackgroundAlpha = 0
+	}
+	cw.SetBackground(background.R, background.G, background.B, settings.BackgroundAlpha)
+	cw.SetScaleAnchor(AnimationEngine.ScaleAnchor(settings.ScaleAnchor))
+	cw.SetIdleState(settings.IdleState)
+}
+
+// publishSettings runs on the render thread once per loop iteration.
+func (cw *CharacterWindow) publishSettings(animation *AnimationEngine.AnimationPlayer) {
+	settings := WindowSettings{
+		State:           animation.GetState(),
+		Flip:            animation.IsFlippedHorizontal(),
+		Opacity:         animation.GetOpacity(),
+		Speed:           animation.GetSpeed(),
+		WindowOpacity:   cw.windowOpacity,
+		AlwaysOnTop:     cw.alwaysOnTop,
+		Gravity:         cw.gravity.enabled,
+		Behavior:        BehaviorIdle,
+		Tint:            hexColor(animation.GetTint()),
+		Reverse:         animation.IsReverse(),
+		Paused:          animation.IsPaused(),
+		Background:      hexColor(cw.bgColor.R, cw.bgColor.G, cw.bgColor.B),
+		BackgroundAlpha: cw.bgColor.A,
+		ScaleAnchor:     string(cw.scaleAnchor),
+		IdleState:       cw.idle.state,
+	}
+	if cw.idle.idle {
+		settings.State = cw.idle.resumeState
+	}
+	if cw.walk.enabled {
+		settings.Behavior = BehaviorWalk
+	}
+
+	if previous := cw.settings.Load(); previous == nil || *previous != settings {
+		cw.settings.Store(&settings)
+	}
+}
+
+func hexColor(r, g, b uint8) string {
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
@@ -0,0 +1,82 @@
+package Window
+
+/*
+snap.go - Edge snapping shared by all character windows
+
+A moved window is pulled flush against the nearest display edge within the
+snap threshold. Every display is considered, so a window straddling two
+monitors snaps to whichever edge it is closest to rather than only the edges
+of the display SDL assigns it to.
+
+Functions:
+- SetSnapThreshold: Set snap distance in pixels for all windows (0 disables)
+- GetSnapThreshold: Get the current snap distance in pixels
+- snappedPosition: Where a moved window snaps to, without moving it
+- snapPosition: Compute the snapped position of a rectangle against displays
+- nearestEdge: Pick the closest edge offset within the threshold on one axis
+*/
+
+import (
+	"sync/atomic"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+var snapThreshold atomic.Int32
+
+func SetSnapThreshold(px int32) {
+	snapThreshold.Store(max(px, 0))
+}
+
+func GetSnapThreshold() int32 {
+	return snapThreshold.Load()
+}
+
+// snappedPosition leaves moving the window to the caller, so the move can go
+// through the drag tracker together with clamping.
+func snappedPosition(window *sdl.Window, x, y int32) sdl.Point {
+	threshold := snapThreshold.Load()
+	if threshold <= 0 {
+		return sdl.Point{X: x, Y: y}
+	}
+
+	var w, h int32
+	if !sdl.GetWindowSize(window, &w, &h) {
+		return sdl.Point{X: x, Y: y}
+	}
+
+	return snapPosition(sdl.Rect{X: x, Y: y, W: w, H: h}, getAllDisplayBounds(), threshold)
+}
+
+func snapPosition(win sdl.Rect, displays []sdl.Rect, threshold int32) sdl.Point {
+	var xEdges, yEdges []int32
+	for _, d := range displays {
+		// Only edges the window could actually sit against: a display's
+		// left/right edges count when the window overlaps it vertically.
+		if win.Y < d.Y+d.H && win.Y+win.H > d.Y {
+			xEdges = append(xEdges, d.X, d.X+d.W-win.W)
+		}
+		if win.X < d.X+d.W && win.X+win.W > d.X {
+			yEdges = append(yEdges, d.Y, d.Y+d.H-win.H)
+		}
+	}
+
+	return sdl.Point{
+		X: nearestEdge(win.X, xEdges, threshold),
+		Y: nearestEdge(win.Y, yEdges, threshold),
+	}
+}
+
+func nearestEdge(pos int32, edges []int32, threshold int32) int32 {
+	best, bestDist := pos, threshold+1
+	for _, edge := range edges {
+		dist := edge - pos
+		if dist < 0 {
+			dist = -dist
+		}
+		if dist <= threshold && dist < bestDist {
+			best, bestDist = edge, dist
+		}
+	}
+	return best
+}
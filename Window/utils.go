@@ -3,16 +3,141 @@ package Window
 /*
 utils.go - Shared utility functions for Window package
 
+SDL invokes the hit test callback with only the window pointer, so per-window
+hit test settings live in a registry keyed by *sdl.Window. Flags owned by the
+CharacterWindow are referenced by pointer so toggling them needs no re-registration.
+Right-button presses are never treated as drags so they reach the event loop.
+Transparent pixels of the current frame never start a drag. HitTestNormal
+only means "don't drag": the press still goes to this window, since SDL has
+no way to hand it to the window underneath. SDL runs the
+callback while pumping events on the window's own thread, so it may read the
+AnimationPlayer without locking. Left presses that start a drag are taken by
+SDL and never reach the event loop as button events, so the callback reports
+them through onDragPress instead.
+
 Functions:
-- hitTestCallback: SDL hit test callback for making windows draggable
+- registerHitTest: Store hit test state for a window
+- unregisterHitTest: Remove a window's hit test state
+- hitTestCallback: SDL hit test callback deciding which pixels drag the window
+- (hitTestState) notePress: Report a new left press on a draggable pixel
+- (hitTestState) releaseLeft: Forget a left press once the button is up again
+- ParseHexColor: Parse a #RRGGBB string into an opaque SDL color
 */
 
 import (
+	"boccho-ui/AnimationEngine"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"unsafe"
 
 	"github.com/jupiterrider/purego-sdl3/sdl"
 )
 
+type hitTestState struct {
+	dragRegion   *AnimationEngine.DragRegion
+	clickThrough *atomic.Bool
+	locked       *atomic.Bool
+	opaqueAt     func(nx, ny float64) bool
+	// onDragPress is called once per left press on a draggable pixel.
+	onDragPress func(x, y float32)
+	// leftDown is only touched on the window's thread. It is cleared by
+	// releaseLeft because SDL doesn't run the callback on every release.
+	leftDown bool
+}
+
+var (
+	hitTestMu     sync.RWMutex
+	hitTestStates = make(map[*sdl.Window]*hitTestState)
+)
+
+func registerHitTest(window *sdl.Window, state *hitTestState) {
+	hitTestMu.Lock()
+	hitTestStates[window] = state
+	hitTestMu.Unlock()
+}
+
+func unregisterHitTest(window *sdl.Window) {
+	hitTestMu.Lock()
+	delete(hitTestStates, window)
+	hitTestMu.Unlock()
+}
+
 func hitTestCallback(window *sdl.Window, point *sdl.Point, data unsafe.Pointer) sdl.HitTestResult {
-	return sdl.HitTestDraggable
+	hitTestMu.RLock()
+	state := hitTestStates[window]
+	hitTestMu.RUnlock()
+
+	if sdl.GetGlobalMouseState(nil, nil)&sdl.ButtonRMask != 0 {
+		return sdl.HitTestNormal
+	}
+
+	if state != nil && (isSet(state.clickThrough) || isSet(state.locked)) {
+		return sdl.HitTestNormal
+	}
+
+	if state == nil {
+		return sdl.HitTestDraggable
+	}
+
+	var w, h int32
+	if !sdl.GetWindowSize(window, &w, &h) || w <= 0 || h <= 0 {
+		return sdl.HitTestDraggable
+	}
+
+	nx := float64(point.X) / float64(w)
+	ny := float64(point.Y) / float64(h)
+	if state.opaqueAt != nil && !state.opaqueAt(nx, ny) {
+		return sdl.HitTestNormal
+	}
+	if state.dragRegion == nil || state.dragRegion.Contains(nx, ny) {
+		state.notePress(point)
+		return sdl.HitTestDraggable
+	}
+	return sdl.HitTestNormal
+}
+
+// notePress calls onDragPress when the left button went down since the last
+// call. SDL also runs the callback for plain mouse movement, which is why the
+// button state is compared rather than every call counted as a press.
+func (s *hitTestState) notePress(point *sdl.Point) {
+	if sdl.GetGlobalMouseState(nil, nil)&sdl.ButtonLMask == 0 {
+		s.leftDown = false
+		return
+	}
+	if s.leftDown {
+		return
+	}
+	s.leftDown = true
+	if s.onDragPress != nil {
+		s.onDragPress(float32(point.X), float32(point.Y))
+	}
+}
+
+// releaseLeft is called from the event loop so a press whose release SDL
+// swallowed doesn't hide the next one. It only queries the mouse after a press.
+func (s *hitTestState) releaseLeft() {
+	if s.leftDown && sdl.GetGlobalMouseState(nil, nil)&sdl.ButtonLMask == 0 {
+		s.leftDown = false
+	}
+}
+
+func isSet(flag *atomic.Bool) bool {
+	return flag != nil && flag.Load()
+}
+
+func ParseHexColor(hex string) (sdl.Color, error) {
+	hex = strings.TrimPrefix(strings.TrimSpace(hex), "#")
+	if len(hex) != 6 {
+		return sdl.Color{}, fmt.Errorf("expected #RRGGBB, got %q", hex)
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return sdl.Color{}, fmt.Errorf("invalid hex color %q: %w", hex, err)
+	}
+
+	return sdl.Color{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 255}, nil
 }
@@ -5,10 +5,70 @@ app.go - Wails application bindings for character management
 
 Exposes to frontend:
 - GetCharacters: List available characters from Frames directory
+- GetCharacterInfo: Read one character's details for the detail panel
+- GetCharactersSorted: List available characters in a chosen order
+- SearchCharacters: List characters whose name or metadata matches a query
+- ToggleFavorite: Mark or unmark a character as a favorite
+- GetFavorites: List favorite character names
+- GetRecentCharacters: List recently spawned characters, most recent first
+- DeleteCharacter: Remove an installed character and close its windows
+- RenameCharacter: Rename an installed character's folder, keeping its windows open
 - SpawnCharacter: Create new SDL character window in separate OS thread
+- SpawnCharacters: Spawn several characters at once
+- DuplicateCharacter: Spawn a copy of an existing window next to it
+- SpawnCharacterOnDisplay: Create new character window centered on a chosen display
+- SpawnCharacterAtCursor: Create new character window centered on the mouse cursor
+- GetDisplays: List connected displays for the display picker
+- GetConfigError: Report problems found in the config file at startup
+- GetMaxWindows: Get the concurrent window limit (0 = unlimited)
 - DestroyCharacter: Close specific character window
+- HideAllCharacters / ShowAllCharacters: Hide or show every window at once
+- SetGlobalScale: Resize every window and make the size the default for new ones
+- PauseAllCharacters / ResumeAllCharacters: Freeze or resume every animation at once
 - GetActiveWindows: List currently spawned windows
 - SetCharacterScale: Adjust scale of specific window
+- SetCharacterReverse: Toggle reverse playback of specific window
+- SetCharacterPaused: Freeze or resume animation of specific window
+- SetCharacterFrame / GetCharacterFrame: Seek and read the frame of specific window
+- SetCharacterFlip: Mirror specific window horizontally
+- SetCharacterOpacity: Make the sprite of specific window translucent
+- SetCharacterTint: Recolor the sprite of specific window from a #RRGGBB string
+- SetCharacterBackground: Fill specific window behind the sprite with a translucent color
+- SetCharacterState / GetCharacterStates: Switch and list animation states
+- SetIdleState: Choose the state a window shows after the idle timeout
+- SetCharacterScaleAnchor: Override the scale anchor of specific window
+- SetCharacterSpeed: Change playback speed multiplier of specific window
+- SetCharacterPosition: Move specific window to screen coordinates
+- GetCharacterPosition: Read current screen position of specific window
+- SetCharacterClickThrough: Stop clicks from dragging specific window (they don't reach windows behind)
+- SetCharacterLocked: Lock or unlock dragging of specific window
+- SetCharacterWindowOpacity: Fade the whole window of specific window
+- SetCharacterAlwaysOnTop: Keep specific window above or among other windows
+- BringCharacterToFront / SendCharacterToBack: Change stacking order of specific window
+- SetCharacterGravity: Let specific window fall to the bottom of its display
+- SetCharacterBehavior: Switch specific window between idle and walk behaviors
+- SetCharacterVisible: Hide or show specific window without destroying it
+- ScreenshotCharacter: Capture specific window as a PNG data URL
+- ExportCharacterGif: Write a character's animation to a looping GIF file
+- GetWindowStats: Read measured FPS and texture memory of specific window
+- GetCharacterRenderInfo: Read the current frame and scaled size of specific window
+- GetPreviewImageBase64: Serve a character's cached preview thumbnail as a data URL
+- ClearThumbnailCache: Remove cached preview thumbnails
+- BrowseBfkFiles / InstallBfkPacks: Pick several packs and install them in one go
+- SetFramesPath: Point the app at another Frames directory and persist it
+- BrowseFramesDir: Pick a Frames directory with a folder dialog
+- InstallBfkFromURL: Download a pack from an https link and install it
+- InstallBfkPackWithOptions: Install a pack, overwriting or skipping installed characters
+- InstallBfkPackWithProgress / CancelInstall: Install a pack in the background with progress events, or stop it
+- UninstallPack / GetInstalledPacks: Remove the characters a pack installed and list installed packs
+- GetBfkPackContents: List every file in a pack without installing it
+- GetPackInstallStatus: Compare a .bfk pack against installed characters
+- SetAutoSpawn: Persist the characters spawned automatically at launch
+- SetPowerSaverMode: Lower frame rate of all windows and persist the choice
+- SetTargetFPS / GetTargetFPS: Choose the render rate of all windows and persist it
+- SetStartOnBoot / GetStartOnBoot: Register the app to launch when the user logs in
+- SaveLayout / ListLayouts / ApplyLayout: Manage named sets of spawned characters with their positions and settings
+- SwitchProfile / ListProfiles / GetActiveProfile: Switch between named config profiles
 */
 
 import (
@@ -16,12 +76,18 @@ import (
 	"boccho-ui/PackManagement"
 	"boccho-ui/Window"
 	"boccho-ui/config"
+	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"image/png"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -29,12 +95,53 @@ import (
 	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
+// cleanupSafetyInterval is how often cleanupDeadWindows rescans for windows
+// whose close notification was missed.
+const cleanupSafetyInterval = 5 * time.Second
+
+// spawnReadyTimeout caps how long a spawn waits for the window to start.
+const spawnReadyTimeout = 2 * time.Second
+
+// closeTimeout caps how long waitForClose waits for each window.
+const closeTimeout = 2 * time.Second
+
 type App struct {
 	ctx           context.Context
 	activeWindows map[string]*Window.CharacterWindow
+	closedWindows chan string
 	mu            sync.RWMutex
+	saveMu        sync.Mutex
 	framesPath    string
 	cfg           config.Config
+	configError   string
+	installs      map[string]context.CancelFunc
+}
+
+// CharacterPosition is returned as a struct because Wails bindings allow at
+// most one value besides an error.
+type CharacterPosition struct {
+	X  int32 `json:"x"`
+	Y  int32 `json:"y"`
+	OK bool  `json:"ok"`
+}
+
+// PackProgress is the payload of "pack:progress", "pack:done" and "pack:error".
+type PackProgress struct {
+	InstallID string `json:"installId"`
+	FilePath  string `json:"filePath"`
+	Done      int    `json:"done"`
+	Total     int    `json:"total"`
+	Error     string `json:"error,omitempty"`
+	Cancelled bool   `json:"cancelled,omitempty"`
+}
+
+// PackInstallResult reports the outcome of one pack in InstallBfkPacks.
+type PackInstallResult struct {
+	FilePath   string   `json:"filePath"`
+	PackName   string   `json:"packName"`
+	Characters []string `json:"characters"`
+	Success    bool     `json:"success"`
+	Error      string   `json:"error,omitempty"`
 }
 
 type CharacterWindowInfo struct {
@@ -42,20 +149,66 @@ type CharacterWindowInfo struct {
 	CharacterName string  `json:"characterName"`
 	IsRunning     bool    `json:"isRunning"`
 	Scale         float64 `json:"scale"`
+	Visible       bool    `json:"visible"`
+	Error         string  `json:"error,omitempty"`
 }
 
 func NewApp() *App {
+	var configError string
 	cfg, err := config.LoadConfig()
-	if err != nil {
+	if errors.Is(err, config.ErrRecoveredFromBackup) {
+		configError = fmt.Sprintf("Config is malformed, loaded the backup copy instead: %v", err)
+	} else if err != nil {
 		fmt.Printf("Error loading config: %v, using defaults\n", err)
+		configError = fmt.Sprintf("Could not read config, using defaults: %v", err)
 		cfg = config.GetDefaultConfig()
+	} else if err := cfg.Validate(); err != nil {
+		fmt.Printf("Invalid config: %v\n", err)
+		configError = fmt.Sprintf("Invalid config: %v", err)
 	}
 
+	applySettings(&cfg)
+
 	return &App{
 		activeWindows: make(map[string]*Window.CharacterWindow),
+		installs:      make(map[string]context.CancelFunc),
+		closedWindows: make(chan string, 32),
 		framesPath:    cfg.FramesPath,
 		cfg:           cfg,
+		configError:   configError,
+	}
+}
+
+// applySettings clamps out-of-range values in cfg and pushes the global
+// window settings to the Window package.
+func applySettings(cfg *config.Config) {
+	if cfg.DefaultScale < AnimationEngine.MinScale {
+		fmt.Printf("Warning: defaultScale %.2f is below %.2f, clamping\n", cfg.DefaultScale, AnimationEngine.MinScale)
+		cfg.DefaultScale = AnimationEngine.MinScale
+	}
+
+	if cfg.TargetFPS <= 0 || cfg.TargetFPS > Window.MaxTargetFPS {
+		fmt.Printf("Warning: targetFPS %d is outside 1-%d, using %d\n", cfg.TargetFPS, Window.MaxTargetFPS, Window.DefaultTargetFPS)
+		cfg.TargetFPS = Window.DefaultTargetFPS
 	}
+
+	Window.SetPowerSaver(cfg.PowerSaver)
+	Window.SetTargetFPS(cfg.TargetFPS)
+	Window.SetAnimatedScale(cfg.AnimatedScale)
+	if !AnimationEngine.ScaleAnchor(cfg.ScaleAnchor).IsValid() {
+		fmt.Printf("Warning: unknown scaleAnchor %q, using %q\n", cfg.ScaleAnchor, AnimationEngine.AnchorCenter)
+		cfg.ScaleAnchor = string(AnimationEngine.AnchorCenter)
+	}
+	Window.SetScaleAnchor(AnimationEngine.ScaleAnchor(cfg.ScaleAnchor))
+	Window.SetSnapThreshold(cfg.SnapThreshold)
+	Window.SetDoubleClickInterval(cfg.DoubleClickMs)
+	Window.SetWalkSpeed(cfg.WalkSpeed)
+	Window.SetIdleTimeout(cfg.IdleTimeoutSeconds)
+	Window.SetKeyBindings(Window.KeyBindings{
+		Close:     cfg.KeyBindings.Close,
+		ScaleUp:   cfg.KeyBindings.ScaleUp,
+		ScaleDown: cfg.KeyBindings.ScaleDown,
+	})
 }
 
 func (a *App) startup(ctx context.Context) {
@@ -65,23 +218,148 @@ func (a *App) startup(ctx context.Context) {
 		fmt.Printf("Error ensuring Frames directory: %v\n", err)
 	}
 
-	fmt.Printf("Frames path: %s\n", a.framesPath)
+	fmt.Printf("Frames path: %s\n", a.framesDir())
 
 	go a.cleanupDeadWindows()
+
+	if err := config.WatchConfig(ctx, a.reloadConfig); err != nil {
+		fmt.Printf("Config hot-reload disabled: %v\n", err)
+	}
+
+	if a.cfg.AutoRestoreSession && len(a.cfg.LastSession) > 0 {
+		a.spawnLayoutWindows("Session restore", a.cfg.LastSession)
+	}
+
+	for _, name := range a.cfg.AutoSpawn {
+		if info := a.SpawnCharacter(name); info.Error != "" {
+			fmt.Printf("Auto-spawn: skipped %s: %s\n", name, info.Error)
+		} else if info.ID == "" {
+			fmt.Printf("Auto-spawn: skipped missing character %s\n", name)
+		}
+	}
+}
+
+func (a *App) shutdown(ctx context.Context) {
+	a.mu.Lock()
+	for _, cw := range a.activeWindows {
+		a.rememberPosition(cw)
+	}
+	a.cfg.LastSession = a.snapshotWindows()
+	a.mu.Unlock()
+
+	if err := a.saveConfig(); err != nil {
+		fmt.Printf("Error saving config on shutdown: %v\n", err)
+	}
+
+	a.DestroyAllCharacters()
+}
+
+// reloadConfig is called by the config watcher after the file is edited on
+// disk. A file that fails to parse keeps the current settings.
+func (a *App) reloadConfig() {
+	var configError string
+	cfg, err := config.LoadConfig()
+	if errors.Is(err, config.ErrRecoveredFromBackup) {
+		configError = fmt.Sprintf("Config is malformed, loaded the backup copy instead: %v", err)
+	} else if err != nil {
+		fmt.Printf("Error reloading config: %v, keeping current settings\n", err)
+		a.mu.Lock()
+		a.configError = fmt.Sprintf("Could not read config, keeping current settings: %v", err)
+		a.mu.Unlock()
+		wailsRuntime.EventsEmit(a.ctx, "config:reloaded")
+		return
+	} else if err := cfg.Validate(); err != nil {
+		fmt.Printf("Invalid config: %v\n", err)
+		configError = fmt.Sprintf("Invalid config: %v", err)
+	}
+
+	a.mu.RLock()
+	// Positions are remembered in memory as windows close and only written on
+	// save, so the in-memory copy is newer than the file.
+	cfg.LastPositions = a.cfg.LastPositions
+	a.mu.RUnlock()
+
+	a.setConfig(cfg, configError)
+}
+
+// setConfig applies cfg as the running config and tells the frontend.
+func (a *App) setConfig(cfg config.Config, configError string) {
+	applySettings(&cfg)
+
+	a.mu.Lock()
+	a.cfg = cfg
+	a.framesPath = cfg.FramesPath
+	a.configError = configError
+	a.mu.Unlock()
+
+	if err := config.EnsureFramesDir(cfg); err != nil {
+		fmt.Printf("Error ensuring Frames directory: %v\n", err)
+	}
+
+	fmt.Printf("Config reloaded (frames path: %s)\n", cfg.FramesPath)
+	wailsRuntime.EventsEmit(a.ctx, "config:reloaded")
+}
+
+// saveConfig writes the running config to disk. Saves run one at a time and
+// each copies a.cfg only once it is its turn, so a save that started earlier
+// can't overwrite a newer one with older settings. Caller must not hold a.mu.
+func (a *App) saveConfig() error {
+	a.saveMu.Lock()
+	defer a.saveMu.Unlock()
+	return a.writeConfig()
+}
+
+// writeConfig is saveConfig for callers that already hold a.saveMu.
+func (a *App) writeConfig() error {
+	a.mu.RLock()
+	cfg := a.cfg.Clone()
+	a.mu.RUnlock()
+	return config.SaveConfig(cfg)
+}
+
+// rememberRecent moves a character to the front of the recent list, dropping
+// duplicates and anything past RecentLimit. Caller must hold a.mu.
+func (a *App) rememberRecent(characterName string) {
+	recent := slices.DeleteFunc(slices.Clone(a.cfg.RecentCharacters), func(name string) bool {
+		return name == characterName
+	})
+	recent = append([]string{characterName}, recent...)
+	a.cfg.RecentCharacters = recent[:min(len(recent), max(a.cfg.RecentLimit, 0))]
+}
+
+// rememberPosition records where a character was last placed. Caller must hold a.mu.
+func (a *App) rememberPosition(cw *Window.CharacterWindow) {
+	x, y, ok := cw.GetPosition()
+	if !ok {
+		return
+	}
+	if a.cfg.LastPositions == nil {
+		a.cfg.LastPositions = make(map[string]config.Position)
+	}
+	a.cfg.LastPositions[cw.GetCharacterName()] = config.Position{X: x, Y: y}
 }
 
 func (a *App) cleanupDeadWindows() {
-	ticker := time.NewTicker(500 * time.Millisecond)
+	ticker := time.NewTicker(cleanupSafetyInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-a.ctx.Done():
 			return
+		case id := <-a.closedWindows:
+			a.mu.Lock()
+			if cw, exists := a.activeWindows[id]; exists {
+				a.rememberPosition(cw)
+				delete(a.activeWindows, id)
+			}
+			a.mu.Unlock()
+			wailsRuntime.EventsEmit(a.ctx, "window:closed", id)
 		case <-ticker.C:
 			a.mu.Lock()
 			for id, cw := range a.activeWindows {
-				if !cw.IsRunning() {
+				if cw.IsDone() {
+					a.rememberPosition(cw)
 					delete(a.activeWindows, id)
 					fmt.Printf("Cleaned up window: %s\n", id)
 				}
@@ -92,39 +370,472 @@ func (a *App) cleanupDeadWindows() {
 }
 
 func (a *App) GetCharacters() []AnimationEngine.CharacterInfo {
-	characters, err := AnimationEngine.ScanCharacters(a.framesPath)
+	characters, err := AnimationEngine.ScanCharacters(a.framesDir())
 	if err != nil {
 		fmt.Printf("Error scanning characters: %v\n", err)
 		return []AnimationEngine.CharacterInfo{}
 	}
+
+	a.mu.RLock()
+	for i := range characters {
+		characters[i].IsFavorite = slices.Contains(a.cfg.Favorites, characters[i].Name)
+	}
+	a.mu.RUnlock()
+
+	return characters
+}
+
+// GetCharacterInfo returns an info with an empty name when the character is
+// missing or has no frames.
+func (a *App) GetCharacterInfo(characterName string) AnimationEngine.CharacterInfo {
+	if _, err := a.characterDir(characterName); err != nil {
+		return AnimationEngine.CharacterInfo{}
+	}
+
+	info, ok := AnimationEngine.LoadCharacterInfo(a.framesDir(), characterName)
+	if !ok {
+		return AnimationEngine.CharacterInfo{}
+	}
+
+	a.mu.RLock()
+	info.IsFavorite = slices.Contains(a.cfg.Favorites, characterName)
+	a.mu.RUnlock()
+	return info
+}
+
+// GetCharactersSorted accepts "name", "frameCount" or "recent"; anything
+// else sorts by name.
+func (a *App) GetCharactersSorted(sortBy string) []AnimationEngine.CharacterInfo {
+	characters := a.GetCharacters()
+	AnimationEngine.SortCharacters(characters, sortBy)
 	return characters
 }
 
+// SearchCharacters matches query case-insensitively against the folder name,
+// display name, author and description. An empty query returns everything.
+func (a *App) SearchCharacters(query string) []AnimationEngine.CharacterInfo {
+	characters := a.GetCharacters()
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return characters
+	}
+
+	matches := []AnimationEngine.CharacterInfo{}
+	for _, char := range characters {
+		for _, field := range []string{char.Name, char.DisplayName, char.Author, char.Description} {
+			if strings.Contains(strings.ToLower(field), query) {
+				matches = append(matches, char)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// ToggleFavorite returns whether the character is a favorite afterwards.
+func (a *App) ToggleFavorite(characterName string) bool {
+	a.mu.Lock()
+	favorite := !slices.Contains(a.cfg.Favorites, characterName)
+	if favorite {
+		a.cfg.Favorites = append(slices.Clone(a.cfg.Favorites), characterName)
+	} else {
+		a.cfg.Favorites = slices.DeleteFunc(slices.Clone(a.cfg.Favorites), func(name string) bool {
+			return name == characterName
+		})
+	}
+	a.mu.Unlock()
+
+	if err := a.saveConfig(); err != nil {
+		fmt.Printf("Warning: Could not save favorites: %v\n", err)
+	}
+	return favorite
+}
+
+func (a *App) GetRecentCharacters() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	recent := slices.Clone(a.cfg.RecentCharacters)
+	if recent == nil {
+		recent = []string{}
+	}
+	return recent
+}
+
+func (a *App) GetFavorites() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	favorites := slices.Clone(a.cfg.Favorites)
+	if favorites == nil {
+		favorites = []string{}
+	}
+	return favorites
+}
+
+// characterDir resolves an installed character's folder, rejecting names that
+// could point outside the Frames directory.
+func (a *App) characterDir(characterName string) (string, error) {
+	if strings.TrimSpace(characterName) == "" {
+		return "", fmt.Errorf("character name cannot be empty")
+	}
+	if strings.ContainsAny(characterName, `/\`) || strings.Contains(characterName, "..") {
+		return "", fmt.Errorf("invalid character name %q", characterName)
+	}
+
+	framesPath := a.framesDir()
+	dir := AnimationEngine.GetCharacterFramesPath(framesPath, characterName)
+	rel, err := filepath.Rel(framesPath, dir)
+	if err != nil || rel != characterName {
+		return "", fmt.Errorf("character %q is outside the Frames directory", characterName)
+	}
+	return dir, nil
+}
+
+// DeleteCharacter removes a character's folder from the Frames directory,
+// closing its open windows first.
+func (a *App) DeleteCharacter(characterName string) error {
+	dir, err := a.characterDir(characterName)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("character %q is not installed", characterName)
+	}
+
+	a.mu.Lock()
+	var closing []*Window.CharacterWindow
+	for id, cw := range a.activeWindows {
+		if cw.GetCharacterName() == characterName {
+			cw.Close()
+			closing = append(closing, cw)
+			delete(a.activeWindows, id)
+		}
+	}
+	delete(a.cfg.LastPositions, characterName)
+	a.cfg.Favorites = slices.DeleteFunc(slices.Clone(a.cfg.Favorites), func(name string) bool {
+		return name == characterName
+	})
+	a.cfg.RecentCharacters = slices.DeleteFunc(slices.Clone(a.cfg.RecentCharacters), func(name string) bool {
+		return name == characterName
+	})
+	a.mu.Unlock()
+
+	// Wait for the render threads to exit so auto-restart can't reload frames
+	// from the folder while it is being removed.
+	waitForClose(closing)
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to delete character %q: %w", characterName, err)
+	}
+
+	fmt.Printf("Deleted character %s (%d windows closed)\n", characterName, len(closing))
+	return a.saveConfig()
+}
+
+// RenameCharacter renames a character's folder in the Frames directory. Open
+// windows keep running under the new name.
+func (a *App) RenameCharacter(oldName, newName string) error {
+	oldDir, err := a.characterDir(oldName)
+	if err != nil {
+		return err
+	}
+	newDir, err := a.characterDir(newName)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(oldDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("character %q is not installed", oldName)
+	}
+	if _, err := os.Stat(newDir); err == nil {
+		return fmt.Errorf("character %q already exists", newName)
+	}
+
+	if err := os.Rename(oldDir, newDir); err != nil {
+		return fmt.Errorf("failed to rename character %q: %w", oldName, err)
+	}
+
+	a.mu.Lock()
+	for _, cw := range a.activeWindows {
+		if cw.GetCharacterName() == oldName {
+			cw.SetCharacterSource(newName, newDir)
+		}
+	}
+	if pos, ok := a.cfg.LastPositions[oldName]; ok {
+		delete(a.cfg.LastPositions, oldName)
+		a.cfg.LastPositions[newName] = pos
+	}
+	for i, name := range a.cfg.AutoSpawn {
+		if name == oldName {
+			a.cfg.AutoSpawn[i] = newName
+		}
+	}
+	for i, name := range a.cfg.Favorites {
+		if name == oldName {
+			a.cfg.Favorites[i] = newName
+		}
+	}
+	for i, name := range a.cfg.RecentCharacters {
+		if name == oldName {
+			a.cfg.RecentCharacters[i] = newName
+		}
+	}
+	a.mu.Unlock()
+
+	fmt.Printf("Renamed character %s to %s\n", oldName, newName)
+	return a.saveConfig()
+}
+
+type spawnOptions struct {
+	scale       float64
+	x, y        int32
+	hasPosition bool
+	settings    *Window.WindowSettings
+	display     int
+	hasDisplay  bool
+	atCursor    bool
+}
+
+// newCharacterWindow builds a window ready to Start, without opening it.
+func (a *App) newCharacterWindow(id, characterName, charPath string, defaults spawnDefaults, opts spawnOptions) *Window.CharacterWindow {
+	charWindow := Window.NewCharacterWindow(id, characterName, charPath, opts.scale)
+	charWindow.SetAutoRestart(defaults.autoRestart)
+	charWindow.SetInitialAlwaysOnTop(defaults.alwaysOnTop)
+	charWindow.SetDefaultScale(defaults.defaultScale)
+	charWindow.OnCrash(a.handleWindowCrash)
+	charWindow.OnContextMenu(a.handleWindowContextMenu)
+	charWindow.OnClose(a.handleWindowClosed)
+	charWindow.OnFail(a.handleWindowFailed)
+	if opts.hasPosition {
+		charWindow.SetInitialPosition(opts.x, opts.y)
+	}
+	if opts.hasDisplay {
+		charWindow.SetInitialDisplay(opts.display)
+	}
+	if opts.atCursor {
+		charWindow.SetInitialCenter(Window.GetCursorPosition())
+	}
+	if opts.settings != nil {
+		charWindow.ApplySettings(*opts.settings)
+		// Created with the saved stacking rather than the default
+		charWindow.SetInitialAlwaysOnTop(opts.settings.AlwaysOnTop)
+	}
+	return charWindow
+}
+
 func (a *App) SpawnCharacter(characterName string) CharacterWindowInfo {
-	charPath := AnimationEngine.GetCharacterFramesPath(a.framesPath, characterName)
+	opts := spawnOptions{scale: a.defaultScale()}
+
+	a.mu.RLock()
+	if pos, ok := a.cfg.LastPositions[characterName]; ok {
+		opts.x, opts.y, opts.hasPosition = pos.X, pos.Y, true
+	}
+	a.mu.RUnlock()
+
+	info := a.spawnCharacter(characterName, opts)
+	if info.ID == "" {
+		return info
+	}
+
+	a.mu.Lock()
+	a.rememberRecent(characterName)
+	a.mu.Unlock()
+
+	if err := a.saveConfig(); err != nil {
+		fmt.Printf("Warning: Could not save recent characters: %v\n", err)
+	}
+	return info
+}
+
+// SpawnCharacters spawns concurrently so the per-window startup delay isn't
+// paid once per character. Characters over the window limit are skipped.
+func (a *App) SpawnCharacters(characterNames []string) []CharacterWindowInfo {
+	results := make([]CharacterWindowInfo, len(characterNames))
+
+	var wg sync.WaitGroup
+	for i, name := range characterNames {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = a.SpawnCharacter(name)
+		}()
+	}
+	wg.Wait()
+
+	spawned := []CharacterWindowInfo{}
+	for _, info := range results {
+		if info.ID != "" {
+			spawned = append(spawned, info)
+		}
+	}
+	return spawned
+}
+
+const duplicateOffset = 30
+
+// DuplicateCharacter spawns another window of the same character, scale and
+// position, offset so the copy doesn't hide the original.
+func (a *App) DuplicateCharacter(windowId string) CharacterWindowInfo {
+	source, exists := a.getWindow(windowId)
+	if !exists {
+		return CharacterWindowInfo{}
+	}
+
+	opts := spawnOptions{scale: source.GetScale()}
+	if x, y, ok := source.GetPosition(); ok {
+		opts.x, opts.y, opts.hasPosition = x+duplicateOffset, y+duplicateOffset, true
+	}
+	return a.spawnCharacter(source.GetCharacterName(), opts)
+}
+
+// SpawnCharacterOnDisplay centers the new window on the display at displayIndex,
+// ignoring any position remembered for the character.
+func (a *App) SpawnCharacterOnDisplay(characterName string, displayIndex int) CharacterWindowInfo {
+	return a.spawnCharacter(characterName, spawnOptions{
+		scale:      a.defaultScale(),
+		display:    displayIndex,
+		hasDisplay: true,
+	})
+}
+
+func (a *App) SpawnCharacterAtCursor(characterName string) CharacterWindowInfo {
+	return a.spawnCharacter(characterName, spawnOptions{
+		scale:    a.defaultScale(),
+		atCursor: true,
+	})
+}
+
+func (a *App) GetDisplays() []Window.DisplayInfo {
+	return Window.GetDisplays()
+}
+
+// liveWindowCount ignores windows whose thread already exited but that the
+// cleanup goroutine has not removed yet. Caller must hold a.mu.
+func (a *App) liveWindowCount() int {
+	count := 0
+	for _, cw := range a.activeWindows {
+		if !cw.IsDone() {
+			count++
+		}
+	}
+	return count
+}
+
+// GetConfigError returns why the config file was rejected at startup, or ""
+// when it loaded cleanly.
+func (a *App) GetConfigError() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.configError
+}
+
+func (a *App) GetMaxWindows() int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.cfg.MaxWindows
+}
+
+func (a *App) defaultScale() float64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.cfg.DefaultScale
+}
+
+// framesDir is the Frames directory characters are scanned, spawned and
+// installed from. It changes when the config is reloaded or SetFramesPath is
+// called, so callers that use it more than once should read it once.
+func (a *App) framesDir() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.framesPath
+}
+
+// spawnDefaults is the part of the config a spawn reads, taken in one go
+// since a config reload or profile switch can replace a.cfg meanwhile.
+type spawnDefaults struct {
+	framesPath   string
+	autoRestart  bool
+	alwaysOnTop  bool
+	defaultScale float64
+}
+
+func (a *App) spawnDefaults() spawnDefaults {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return spawnDefaults{
+		framesPath:   a.framesPath,
+		autoRestart:  a.cfg.AutoRestart,
+		alwaysOnTop:  a.cfg.DefaultAlwaysOnTop,
+		defaultScale: a.cfg.DefaultScale,
+	}
+}
+
+func (a *App) spawnCharacter(characterName string, opts spawnOptions) CharacterWindowInfo {
+	defaults := a.spawnDefaults()
+	framesPath := defaults.framesPath
+	charPath := AnimationEngine.GetCharacterFramesPath(framesPath, characterName)
 
 	if _, err := os.Stat(charPath); os.IsNotExist(err) {
 		fmt.Printf("Character path not found: %s\n", charPath)
 		return CharacterWindowInfo{}
 	}
+	if err := AnimationEngine.ValidateCharacter(framesPath, characterName); err != nil {
+		fmt.Printf("Not spawning %s: %v\n", characterName, err)
+		return CharacterWindowInfo{CharacterName: characterName, Error: err.Error()}
+	}
 
 	id := uuid.New().String()[:8]
-
-	charWindow := Window.NewCharacterWindow(id, characterName, charPath)
+	charWindow := a.newCharacterWindow(id, characterName, charPath, defaults, opts)
 
 	a.mu.Lock()
+	if limit := a.cfg.MaxWindows; limit > 0 && a.liveWindowCount() >= limit {
+		a.mu.Unlock()
+		fmt.Printf("Not spawning %s: window limit of %d reached\n", characterName, limit)
+		return CharacterWindowInfo{
+			CharacterName: characterName,
+			Error:         fmt.Sprintf("window limit of %d reached", limit),
+		}
+	}
 	a.activeWindows[id] = charWindow
 	a.mu.Unlock()
 
 	charWindow.Start()
 
-	time.Sleep(50 * time.Millisecond)
+	// Waiting for the first frame lets a window that fails to start report
+	// why. Slow-loading characters are returned as running after the timeout.
+	select {
+	case <-charWindow.Ready():
+	case <-charWindow.Done():
+	case <-time.After(spawnReadyTimeout):
+	}
 
 	return CharacterWindowInfo{
 		ID:            id,
 		CharacterName: characterName,
 		IsRunning:     charWindow.IsRunning(),
 		Scale:         charWindow.GetScale(),
+		Visible:       charWindow.IsVisible(),
+		Error:         charWindow.GetError(),
+	}
+}
+
+func (a *App) handleWindowCrash(info Window.CrashInfo) {
+	fmt.Printf("Window %s (%s) crashed: %s\n", info.WindowID, info.CharacterName, info.Reason)
+	wailsRuntime.EventsEmit(a.ctx, "character:crashed", info)
+}
+
+func (a *App) handleWindowFailed(info Window.FailureInfo) {
+	wailsRuntime.EventsEmit(a.ctx, "window:failed", info)
+}
+
+func (a *App) handleWindowContextMenu(info Window.ContextMenuInfo) {
+	wailsRuntime.EventsEmit(a.ctx, "character:contextmenu", info)
+}
+
+func (a *App) handleWindowClosed(windowId string) {
+	select {
+	case a.closedWindows <- windowId:
+	case <-a.ctx.Done():
 	}
 }
 
@@ -140,12 +851,25 @@ func (a *App) DestroyCharacter(windowId string) bool {
 	charWindow.Close()
 
 	a.mu.Lock()
+	a.rememberPosition(charWindow)
 	delete(a.activeWindows, windowId)
 	a.mu.Unlock()
 
 	return true
 }
 
+// waitForClose waits for windows that were told to close to exit their
+// render threads, giving up on each after closeTimeout.
+func waitForClose(windows []*Window.CharacterWindow) {
+	for _, cw := range windows {
+		select {
+		case <-cw.Done():
+		case <-time.After(closeTimeout):
+			fmt.Printf("Warning: Window %s of %s did not close in time\n", cw.GetID(), cw.GetCharacterName())
+		}
+	}
+}
+
 func (a *App) GetActiveWindows() []CharacterWindowInfo {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -158,6 +882,7 @@ func (a *App) GetActiveWindows() []CharacterWindowInfo {
 				CharacterName: cw.GetCharacterName(),
 				IsRunning:     true,
 				Scale:         cw.GetScale(),
+				Visible:       cw.IsVisible(),
 			})
 		}
 	}
@@ -174,11 +899,66 @@ func (a *App) DestroyAllCharacters() {
 	a.activeWindows = make(map[string]*Window.CharacterWindow)
 }
 
-func (a *App) SetCharacterScale(windowId string, scale float64) bool {
-	a.mu.RLock()
-	charWindow, exists := a.activeWindows[windowId]
-	a.mu.RUnlock()
+func (a *App) HideAllCharacters() {
+	a.setAllVisible(false)
+}
+
+func (a *App) ShowAllCharacters() {
+	a.setAllVisible(true)
+}
 
+func (a *App) setAllVisible(visible bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	for _, cw := range a.activeWindows {
+		cw.SetVisible(visible)
+	}
+}
+
+// SetGlobalScale applies scale to every active window, saves it as the
+// default for new windows and returns how many windows were updated.
+func (a *App) SetGlobalScale(scale float64) (int, error) {
+	scale = max(scale, AnimationEngine.MinScale)
+
+	a.mu.Lock()
+	for _, cw := range a.activeWindows {
+		cw.SetScale(scale)
+	}
+	count := len(a.activeWindows)
+	a.cfg.DefaultScale = scale
+	a.mu.Unlock()
+
+	return count, a.saveConfig()
+}
+
+func (a *App) PauseAllCharacters() {
+	a.setAllPaused(true)
+}
+
+func (a *App) ResumeAllCharacters() {
+	a.setAllPaused(false)
+}
+
+func (a *App) setAllPaused(paused bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	for _, cw := range a.activeWindows {
+		cw.SetPaused(paused)
+	}
+}
+
+func (a *App) getWindow(windowId string) (*Window.CharacterWindow, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	charWindow, exists := a.activeWindows[windowId]
+	return charWindow, exists
+}
+
+func (a *App) SetCharacterScale(windowId string, scale float64) bool {
+	charWindow, exists := a.getWindow(windowId)
 	if !exists {
 		return false
 	}
@@ -187,24 +967,354 @@ func (a *App) SetCharacterScale(windowId string, scale float64) bool {
 	return true
 }
 
+func (a *App) SetCharacterReverse(windowId string, reverse bool) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	charWindow.SetReverse(reverse)
+	return true
+}
+
+func (a *App) SetCharacterPaused(windowId string, paused bool) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	charWindow.SetPaused(paused)
+	return true
+}
+
+func (a *App) SetCharacterFrame(windowId string, frame int) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	charWindow.SeekTo(frame)
+	return true
+}
+
+func (a *App) GetCharacterFrame(windowId string) int {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return -1
+	}
+
+	return charWindow.GetCurrentFrame()
+}
+
+func (a *App) SetCharacterFlip(windowId string, flip bool) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	charWindow.SetFlipHorizontal(flip)
+	return true
+}
+
+func (a *App) SetCharacterOpacity(windowId string, alpha float64) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	charWindow.SetOpacity(alpha)
+	return true
+}
+
+func (a *App) SetCharacterTint(windowId, hex string) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	tint, err := Window.ParseHexColor(hex)
+	if err != nil {
+		fmt.Printf("Invalid tint %q: %v\n", hex, err)
+		return false
+	}
+
+	charWindow.SetTint(tint)
+	return true
+}
+
+// SetCharacterBackground takes alpha in the range 0..1; 0 makes the window
+// fully transparent again.
+func (a *App) SetCharacterBackground(windowId, hex string, alpha float32) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	color, err := Window.ParseHexColor(hex)
+	if err != nil {
+		fmt.Printf("Invalid background %q: %v\n", hex, err)
+		return false
+	}
+
+	alpha = min(max(alpha, 0), 1)
+	charWindow.SetBackground(color.R, color.G, color.B, uint8(alpha*255+0.5))
+	return true
+}
+
+func (a *App) SetCharacterState(windowId, state string) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	if !slices.Contains(a.GetCharacterStates(charWindow.GetCharacterName()), state) {
+		return false
+	}
+
+	charWindow.SetState(state)
+	return true
+}
+
+// SetIdleState chooses the state a window switches to after the idle
+// timeout; the state must exist for the window's character.
+func (a *App) SetIdleState(windowId, state string) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	if !slices.Contains(a.GetCharacterStates(charWindow.GetCharacterName()), state) {
+		return false
+	}
+
+	charWindow.SetIdleState(state)
+	return true
+}
+
+// SetCharacterScaleAnchor takes "topLeft", "center" or "bottomCenter"; ""
+// makes the window follow the configured scaleAnchor again.
+func (a *App) SetCharacterScaleAnchor(windowId, anchor string) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	if anchor != "" && !AnimationEngine.ScaleAnchor(anchor).IsValid() {
+		return false
+	}
+
+	charWindow.SetScaleAnchor(AnimationEngine.ScaleAnchor(anchor))
+	return true
+}
+
+func (a *App) GetCharacterStates(characterName string) []string {
+	states, err := AnimationEngine.GetCharacterStates(a.framesDir(), characterName)
+	if err != nil {
+		fmt.Printf("Error reading states of %s: %v\n", characterName, err)
+		return []string{}
+	}
+	return states
+}
+
+func (a *App) SetCharacterSpeed(windowId string, speed float64) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	charWindow.SetSpeed(speed)
+	return true
+}
+
+func (a *App) SetCharacterPosition(windowId string, x, y int32) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists || !charWindow.IsRunning() {
+		return false
+	}
+
+	charWindow.SetPosition(x, y)
+	return true
+}
+
+func (a *App) GetCharacterPosition(windowId string) CharacterPosition {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists || !charWindow.IsRunning() {
+		return CharacterPosition{}
+	}
+
+	x, y, ok := charWindow.GetPosition()
+	return CharacterPosition{X: x, Y: y, OK: ok}
+}
+
+func (a *App) SetCharacterClickThrough(windowId string, enabled bool) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	charWindow.SetClickThrough(enabled)
+	return true
+}
+
+func (a *App) SetCharacterLocked(windowId string, locked bool) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	charWindow.SetLocked(locked)
+	return true
+}
+
+func (a *App) SetCharacterWindowOpacity(windowId string, opacity float32) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	charWindow.SetWindowOpacity(opacity)
+	return true
+}
+
+func (a *App) SetCharacterAlwaysOnTop(windowId string, onTop bool) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	charWindow.SetAlwaysOnTop(onTop)
+	return true
+}
+
+func (a *App) BringCharacterToFront(windowId string) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	charWindow.BringToFront()
+	return true
+}
+
+func (a *App) SendCharacterToBack(windowId string) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	charWindow.SendToBack()
+	return true
+}
+
+func (a *App) SetCharacterGravity(windowId string, enabled bool) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	charWindow.SetGravity(enabled)
+	return true
+}
+
+func (a *App) SetCharacterBehavior(windowId, behavior string) bool {
+	if !Window.IsValidBehavior(behavior) {
+		return false
+	}
+
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	charWindow.SetBehavior(behavior)
+	return true
+}
+
+func (a *App) SetCharacterVisible(windowId string, visible bool) bool {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return false
+	}
+
+	charWindow.SetVisible(visible)
+	return true
+}
+
+func (a *App) ScreenshotCharacter(windowId string) (string, error) {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return "", fmt.Errorf("window %s not found", windowId)
+	}
+
+	img, err := charWindow.Capture()
+	if err != nil {
+		return "", fmt.Errorf("failed to capture window %s: %w", windowId, err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", fmt.Errorf("failed to encode screenshot: %w", err)
+	}
+
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func (a *App) ExportCharacterGif(characterName string, outputPath string, fps int) error {
+	charPath, err := a.characterDir(characterName)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(charPath); err != nil {
+		return fmt.Errorf("character %s not found: %w", characterName, err)
+	}
+
+	if err := AnimationEngine.ExportGif(charPath, outputPath, fps); err != nil {
+		return fmt.Errorf("failed to export %s: %w", characterName, err)
+	}
+	return nil
+}
+
+func (a *App) GetWindowStats(windowId string) Window.WindowStats {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return Window.WindowStats{}
+	}
+	return charWindow.GetStats()
+}
+
+func (a *App) GetCharacterRenderInfo(windowId string) Window.RenderInfo {
+	charWindow, exists := a.getWindow(windowId)
+	if !exists {
+		return Window.RenderInfo{}
+	}
+	return charWindow.GetRenderInfo()
+}
+
 func (a *App) GetPreviewImageBase64(characterName string) string {
-	previewPath, err := AnimationEngine.GetPreviewImage(a.framesPath, characterName)
+	previewPath, err := AnimationEngine.GetPreviewImage(a.framesDir(), characterName)
 	if err != nil {
 		return ""
 	}
 
-	data, err := os.ReadFile(previewPath)
+	data, err := AnimationEngine.GetThumbnail(thumbnailCacheDir(), characterName, previewPath)
 	if err != nil {
+		fmt.Printf("Warning: Could not create thumbnail for %s: %v\n", characterName, err)
 		return ""
 	}
 
 	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(data)
 }
 
-func (a *App) GetPreviewFrames(characterName string, maxFrames int) []string {
-	charPath := AnimationEngine.GetCharacterFramesPath(a.framesPath, characterName)
+func thumbnailCacheDir() string {
+	return filepath.Join(config.GetAppDataDir(), "thumbnails")
+}
 
-	entries, err := os.ReadDir(charPath)
+func (a *App) ClearThumbnailCache() error {
+	return AnimationEngine.ClearThumbnailCache(thumbnailCacheDir())
+}
+
+func (a *App) GetPreviewFrames(characterName string, maxFrames int) []string {
+	files, err := AnimationEngine.FindPreviewFrames(a.framesDir(), characterName)
 	if err != nil {
 		return []string{}
 	}
@@ -212,28 +1322,14 @@ func (a *App) GetPreviewFrames(characterName string, maxFrames int) []string {
 	var frames []string
 	count := 0
 
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		name := entry.Name()
-		ext := ""
-		if len(name) > 4 {
-			ext = name[len(name)-4:]
-		}
-
-		if ext != ".png" && ext != ".jpg" && ext != ".PNG" && ext != ".JPG" {
-			continue
-		}
-
-		framePath := charPath + "/" + name
-		data, err := os.ReadFile(framePath)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
 		if err != nil {
 			continue
 		}
 
-		frames = append(frames, "data:image/png;base64,"+base64.StdEncoding.EncodeToString(data))
+		mimeType := AnimationEngine.FrameMIMEType(file)
+		frames = append(frames, "data:"+mimeType+";base64,"+base64.StdEncoding.EncodeToString(data))
 		count++
 
 		if maxFrames > 0 && count >= maxFrames {
@@ -245,9 +1341,12 @@ func (a *App) GetPreviewFrames(characterName string, maxFrames int) []string {
 }
 
 func (a *App) OpenFramesDir() error {
-	framesPath := a.cfg.FramesPath
+	a.mu.RLock()
+	cfg := a.cfg.Clone()
+	a.mu.RUnlock()
+	framesPath := cfg.FramesPath
 
-	if err := config.EnsureFramesDir(a.cfg); err != nil {
+	if err := config.EnsureFramesDir(cfg); err != nil {
 		return err
 	}
 
@@ -268,7 +1367,7 @@ func (a *App) OpenConfig() error {
 	configPath := config.GetConfigPath()
 
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		if err := config.SaveConfig(a.cfg); err != nil {
+		if err := a.saveConfig(); err != nil {
 			return err
 		}
 	}
@@ -296,20 +1395,63 @@ func (a *App) OpenConfig() error {
 }
 
 func (a *App) GetFramesPath() string {
-	return a.cfg.FramesPath
+	return a.framesDir()
 }
 
 func (a *App) GetConfigPath() string {
 	return config.GetConfigPath()
 }
 
+// SetFramesPath only affects characters spawned and scanned afterwards; open
+// windows keep playing from the folder they were loaded from.
+func (a *App) SetFramesPath(path string) error {
+	a.mu.RLock()
+	cfg := a.cfg.Clone()
+	a.mu.RUnlock()
+
+	cfg.FramesPath = filepath.Clean(strings.TrimSpace(path))
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if err := config.EnsureFramesDir(cfg); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.cfg.FramesPath = cfg.FramesPath
+	a.framesPath = cfg.FramesPath
+	a.mu.Unlock()
+
+	if err := a.saveConfig(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Frames path set to %s\n", cfg.FramesPath)
+	wailsRuntime.EventsEmit(a.ctx, "config:reloaded")
+	return nil
+}
+
+// BrowseFramesDir returns an empty string when the dialog is cancelled.
+func (a *App) BrowseFramesDir() string {
+	dir, err := wailsRuntime.OpenDirectoryDialog(a.ctx, wailsRuntime.OpenDialogOptions{
+		Title:                "Select Frames Directory",
+		DefaultDirectory:     a.GetFramesPath(),
+		CanCreateDirectories: true,
+	})
+	if err != nil {
+		fmt.Printf("Error opening directory dialog: %v\n", err)
+		return ""
+	}
+	return dir
+}
+
 func (a *App) BrowseBfkFile() string {
 	filePath, err := wailsRuntime.OpenFileDialog(a.ctx, wailsRuntime.OpenDialogOptions{
 		Title: "Select Boccho Frame Pack",
 		Filters: []wailsRuntime.FileFilter{
 			{
-				DisplayName: "Boccho Frame Pack (*.bfk)",
-				Pattern:     "*.bfk",
+				DisplayName: "Boccho Frame Pack (*.bfk, *.zip)",
+				Pattern:     "*.bfk;*.zip",
 			},
 		},
 	})
@@ -320,10 +1462,395 @@ func (a *App) BrowseBfkFile() string {
 	return filePath
 }
 
+func (a *App) BrowseBfkFiles() []string {
+	filePaths, err := wailsRuntime.OpenMultipleFilesDialog(a.ctx, wailsRuntime.OpenDialogOptions{
+		Title: "Select Boccho Frame Packs",
+		Filters: []wailsRuntime.FileFilter{
+			{
+				DisplayName: "Boccho Frame Pack (*.bfk, *.zip)",
+				Pattern:     "*.bfk;*.zip",
+			},
+		},
+	})
+	if err != nil {
+		fmt.Printf("Error opening file dialog: %v\n", err)
+		return []string{}
+	}
+	return filePaths
+}
+
+// InstallBfkPacks installs each pack in turn, overwriting installed
+// characters. A pack that fails is reported and the rest still install.
+func (a *App) InstallBfkPacks(filePaths []string) []PackInstallResult {
+	results := make([]PackInstallResult, 0, len(filePaths))
+	framesPath := a.framesDir()
+	for _, filePath := range filePaths {
+		result := PackInstallResult{FilePath: filePath, Characters: []string{}}
+
+		info, err := PackManagement.ValidateBfkPack(filePath)
+		if err == nil {
+			result.PackName = info.PackName
+			err = PackManagement.InstallPack(context.Background(), filePath, framesPath)
+		}
+		if err != nil {
+			fmt.Printf("Error installing pack %s: %v\n", filePath, err)
+			result.Error = err.Error()
+		} else {
+			result.Success = true
+			result.Characters = info.Characters
+		}
+
+		results = append(results, result)
+	}
+	return results
+}
+
 func (a *App) GetBfkPackInfo(filePath string) PackManagement.PackInfo {
-	return PackManagement.GetPackInfo(filePath)
+	info := PackManagement.GetPackInfo(filePath)
+	if info.Error != "" {
+		return info
+	}
+
+	conflicts, err := PackManagement.CheckConflicts(filePath, a.framesDir())
+	if err != nil {
+		fmt.Printf("Error checking pack conflicts: %v\n", err)
+	}
+	info.Conflicts = conflicts
+	return info
+}
+
+func (a *App) InstallBfkFromURL(url string) error {
+	return PackManagement.InstallPackFromURL(context.Background(), strings.TrimSpace(url), a.framesDir())
 }
 
 func (a *App) InstallBfkPack(filePath string) error {
-	return PackManagement.InstallPack(filePath, a.cfg.FramesPath)
+	return PackManagement.InstallPack(context.Background(), filePath, a.framesDir())
+}
+
+// InstallBfkPackWithOptions installs a pack, either overwriting characters that
+// are already installed or leaving them untouched.
+func (a *App) InstallBfkPackWithOptions(filePath string, overwrite bool) error {
+	return PackManagement.InstallPackWithOptions(context.Background(), filePath, a.framesDir(), PackManagement.InstallOptions{
+		Overwrite: overwrite,
+	})
+}
+
+// InstallBfkPackWithProgress starts installing a pack in the background and
+// returns an install ID for CancelInstall. Progress is reported with
+// "pack:progress" events followed by "pack:done" or "pack:error".
+func (a *App) InstallBfkPackWithProgress(filePath string, overwrite bool) (string, error) {
+	installID := uuid.New().String()[:8]
+	ctx, cancel := context.WithCancel(context.Background())
+	// Read before starting so the install isn't split across Frames directories.
+	framesPath := a.framesDir()
+
+	a.mu.Lock()
+	a.installs[installID] = cancel
+	a.mu.Unlock()
+
+	go func() {
+		defer func() {
+			a.mu.Lock()
+			delete(a.installs, installID)
+			a.mu.Unlock()
+			cancel()
+		}()
+
+		lastPercent := -1
+		progress := PackProgress{InstallID: installID, FilePath: filePath}
+		err := PackManagement.InstallPackWithOptions(ctx, filePath, framesPath, PackManagement.InstallOptions{
+			Overwrite: overwrite,
+			Progress: func(done, total int) {
+				progress.Done, progress.Total = done, total
+				// Limit events to one per percent so large packs don't flood the frontend.
+				percent := 100
+				if total > 0 {
+					percent = done * 100 / total
+				}
+				if percent != lastPercent {
+					lastPercent = percent
+					wailsRuntime.EventsEmit(a.ctx, "pack:progress", progress)
+				}
+			},
+		})
+		if err != nil {
+			fmt.Printf("Error installing pack %s: %v\n", filePath, err)
+			progress.Error = err.Error()
+			progress.Cancelled = errors.Is(err, context.Canceled)
+			wailsRuntime.EventsEmit(a.ctx, "pack:error", progress)
+			return
+		}
+		wailsRuntime.EventsEmit(a.ctx, "pack:done", progress)
+	}()
+
+	return installID, nil
+}
+
+// CancelInstall stops an install started by InstallBfkPackWithProgress. The
+// install then rolls back and reports "pack:error" with cancelled set.
+func (a *App) CancelInstall(installId string) bool {
+	a.mu.RLock()
+	cancel, ok := a.installs[installId]
+	a.mu.RUnlock()
+
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+// UninstallPack deletes the character folders a pack installed last, closing
+// their windows. Folders already deleted or renamed by hand are skipped.
+func (a *App) UninstallPack(packName string) error {
+	characters, err := PackManagement.GetPackCharacters(packName)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range characters {
+		dir, err := a.characterDir(name)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			continue
+		}
+		if err := a.DeleteCharacter(name); err != nil {
+			return fmt.Errorf("failed to uninstall pack %q: %w", packName, err)
+		}
+	}
+
+	if err := PackManagement.ForgetPack(packName); err != nil {
+		return err
+	}
+	fmt.Printf("Uninstalled pack %s (%d characters)\n", packName, len(characters))
+	return nil
+}
+
+// GetInstalledPacks maps installed pack names to the character folders they own.
+func (a *App) GetInstalledPacks() map[string][]string {
+	registry, err := PackManagement.LoadInstalledPacks()
+	if err != nil || registry.Packs == nil {
+		if err != nil {
+			fmt.Printf("Error reading installed packs: %v\n", err)
+		}
+		return map[string][]string{}
+	}
+	return registry.Packs
+}
+
+func (a *App) GetBfkPackContents(filePath string) []PackManagement.PackEntry {
+	entries, err := PackManagement.ListPackContents(filePath)
+	if err != nil {
+		fmt.Printf("Error listing pack contents: %v\n", err)
+		return []PackManagement.PackEntry{}
+	}
+	return entries
+}
+
+func (a *App) GetPackInstallStatus(filePath string) PackManagement.PackInstallStatus {
+	return PackManagement.GetPackInstallStatus(filePath, a.framesDir())
+}
+
+func (a *App) SetPowerSaverMode(enabled bool) error {
+	Window.SetPowerSaver(enabled)
+
+	a.mu.Lock()
+	a.cfg.PowerSaver = enabled
+	a.mu.Unlock()
+
+	return a.saveConfig()
+}
+
+func (a *App) SetAutoSpawn(names []string) error {
+	a.mu.Lock()
+	a.cfg.AutoSpawn = slices.Clone(names)
+	a.mu.Unlock()
+
+	return a.saveConfig()
+}
+
+func (a *App) GetPowerSaverMode() bool {
+	return Window.IsPowerSaver()
+}
+
+func (a *App) SetTargetFPS(fps int) error {
+	if fps <= 0 || fps > Window.MaxTargetFPS {
+		return fmt.Errorf("target FPS must be between 1 and %d", Window.MaxTargetFPS)
+	}
+	Window.SetTargetFPS(fps)
+
+	a.mu.Lock()
+	a.cfg.TargetFPS = fps
+	a.mu.Unlock()
+
+	return a.saveConfig()
+}
+
+func (a *App) GetTargetFPS() int {
+	return Window.GetTargetFPS()
+}
+
+// SetStartOnBoot is stored by the OS rather than in the config, so it is
+// shared by every profile.
+func (a *App) SetStartOnBoot(enabled bool) error {
+	if err := config.SetLaunchOnStartup(enabled); err != nil {
+		return err
+	}
+	fmt.Printf("Launch on startup set to: %v\n", enabled)
+	return nil
+}
+
+func (a *App) GetStartOnBoot() bool {
+	enabled, err := config.IsLaunchOnStartup()
+	if err != nil {
+		fmt.Printf("Could not check launch on startup: %v\n", err)
+	}
+	return enabled
+}
+
+func (a *App) SaveLayout(name string) error {
+	a.mu.RLock()
+	layout := config.Layout{Name: name, Windows: a.snapshotWindows()}
+	a.mu.RUnlock()
+
+	return config.SaveLayout(layout)
+}
+
+// snapshotWindows lists running windows as layout entries. Caller must hold a.mu.
+func (a *App) snapshotWindows() []config.LayoutWindow {
+	windows := []config.LayoutWindow{}
+	for _, cw := range a.activeWindows {
+		if !cw.IsRunning() {
+			continue
+		}
+		x, y, hasPosition := cw.GetPosition()
+		window := config.LayoutWindow{
+			CharacterName: cw.GetCharacterName(),
+			Scale:         cw.GetScale(),
+			X:             x,
+			Y:             y,
+			HasPosition:   hasPosition,
+		}
+		if settings, ok := cw.GetSettings(); ok {
+			saved := config.WindowSettings(settings)
+			window.Settings = &saved
+		}
+		windows = append(windows, window)
+	}
+	return windows
+}
+
+// spawnLayoutWindows skips entries whose character no longer exists or that
+// exceed the window limit, logging each under label.
+func (a *App) spawnLayoutWindows(label string, windows []config.LayoutWindow) {
+	for _, w := range windows {
+		scale := w.Scale
+		if scale <= 0 {
+			scale = a.defaultScale()
+		}
+		opts := spawnOptions{
+			scale:       scale,
+			x:           w.X,
+			y:           w.Y,
+			hasPosition: w.HasPosition,
+		}
+		if w.Settings != nil {
+			settings := Window.WindowSettings(*w.Settings)
+			opts.settings = &settings
+		}
+		info := a.spawnCharacter(w.CharacterName, opts)
+		if info.Error != "" {
+			fmt.Printf("%s: skipped %s: %s\n", label, w.CharacterName, info.Error)
+		} else if info.ID == "" {
+			fmt.Printf("%s: skipped missing character %s\n", label, w.CharacterName)
+		}
+	}
+}
+
+func (a *App) ListLayouts() []string {
+	names, err := config.ListLayouts()
+	if err != nil {
+		fmt.Printf("Error listing layouts: %v\n", err)
+		return []string{}
+	}
+	return names
+}
+
+func (a *App) ApplyLayout(name string) error {
+	layout, err := config.LoadLayout(name)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	closing := make([]*Window.CharacterWindow, 0, len(a.activeWindows))
+	for _, cw := range a.activeWindows {
+		closing = append(closing, cw)
+	}
+	a.mu.Unlock()
+	a.DestroyAllCharacters()
+
+	// The old windows must be gone before the new ones open, or they would
+	// overlap and their last position updates would land after the new ones.
+	waitForClose(closing)
+
+	a.spawnLayoutWindows(fmt.Sprintf("Layout %q", name), layout.Windows)
+
+	return nil
+}
+
+// SwitchProfile saves the current settings to the active profile, then loads
+// and applies the named one, creating it with defaults if it doesn't exist.
+// Open windows keep running.
+func (a *App) SwitchProfile(name string) error {
+	if err := config.ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	// Held until the new profile is applied, so no save in between can write
+	// the old settings into the new profile's file.
+	a.saveMu.Lock()
+	defer a.saveMu.Unlock()
+
+	a.mu.Lock()
+	for _, cw := range a.activeWindows {
+		a.rememberPosition(cw)
+	}
+	a.mu.Unlock()
+
+	if err := a.writeConfig(); err != nil {
+		return fmt.Errorf("failed to save current profile: %w", err)
+	}
+
+	var configError string
+	cfg, err := config.LoadProfile(name)
+	if errors.Is(err, config.ErrRecoveredFromBackup) {
+		configError = fmt.Sprintf("Config is malformed, loaded the backup copy instead: %v", err)
+	} else if err != nil {
+		return fmt.Errorf("failed to load profile %q: %w", name, err)
+	} else if err := cfg.Validate(); err != nil {
+		configError = fmt.Sprintf("Invalid config: %v", err)
+	}
+
+	if err := config.SetActiveProfile(name); err != nil {
+		return err
+	}
+
+	fmt.Printf("Switched to profile %q\n", name)
+	a.setConfig(cfg, configError)
+	return nil
+}
+
+func (a *App) ListProfiles() []string {
+	names, err := config.ListProfiles()
+	if err != nil {
+		fmt.Printf("Error listing profiles: %v\n", err)
+		return []string{config.DefaultProfile}
+	}
+	return names
+}
+
+func (a *App) GetActiveProfile() string {
+	return config.GetActiveProfile()
 }
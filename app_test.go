@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"boccho-ui/AnimationEngine"
+	"boccho-ui/config"
+)
+
+// TestSpawnScale resolves the scale from the config the way SpawnCharacter
+// does and builds the window and player the way spawnCharacter and the
+// render thread do, without opening a window.
+func TestSpawnScale(t *testing.T) {
+	a := &App{cfg: config.Config{DefaultScale: 0.75}}
+
+	tests := []struct {
+		name string
+		opts spawnOptions
+		want float64
+	}{
+		{"default scale", spawnOptions{scale: a.defaultScale()}, 0.75},
+		{"restored scale", spawnOptions{scale: 1.2}, 1.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			charPath := t.TempDir()
+			cw := a.newCharacterWindow("test", "Hero", charPath, a.spawnDefaults(), tt.opts)
+
+			if got := cw.GetScale(); got != tt.want {
+				t.Errorf("window reports scale %v, want %v", got, tt.want)
+			}
+			player := AnimationEngine.NewAnimationPlayer(charPath, cw.GetScale())
+			if got := player.GetScale(); got != tt.want {
+				t.Errorf("player reports scale %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,23 +5,92 @@ config.go - Configuration management for boccho-ui
 
 Functions:
 - GetDefaultConfig: Returns default configuration with standard paths
-- LoadConfig: Loads config from boccho.config.json or creates default
-- SaveConfig: Saves current config to boccho.config.json
-- GetConfigPath: Returns the path to boccho.config.json
+- (Config) Clone: Returns a copy that shares no maps or slices with the original
+- LoadConfig: Loads the active profile's config or creates default
+- SaveConfig: Saves current config to the active profile's file
+- GetConfigPath: Returns the path of the active profile's config file
+- loadConfigFile / saveConfigFile: Load and save a config at a given path
+- getBackupPath: Returns the path of the previous copy of a config file
+- decodeConfig: Decodes config JSON on top of the defaults
+- writeFileAtomic: Writes a file through a temp file and rename
 - getDefaultFramesPath: Returns default frames path
 - GetAppDataDir: Returns app data directory for current OS
 */
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 )
 
+const (
+	// DefaultSnapThreshold is the edge snap distance in pixels for new configs.
+	DefaultSnapThreshold = 20
+	DefaultScale         = 0.51 // initial scale for newly spawned characters
+	DefaultDoubleClickMs = 300
+	DefaultWalkSpeed     = 60.0 // px/s
+	DefaultMaxWindows    = 10   // 0 means unlimited
+	DefaultRecentLimit   = 8    // 0 disables recent characters
+	DefaultTargetFPS     = 60
+	DefaultScaleAnchor   = "center"
+)
+
+// ErrRecoveredFromBackup is returned by LoadConfig together with a usable
+// config when boccho.config.json was malformed and the .bak copy was loaded.
+var ErrRecoveredFromBackup = errors.New("config recovered from backup")
+
+type Position struct {
+	X int32 `json:"x"`
+	Y int32 `json:"y"`
+}
+
+// KeyBindings holds SDL key names per window action; an empty name disables it.
+type KeyBindings struct {
+	Close     string `json:"close"`
+	ScaleUp   string `json:"scaleUp"`
+	ScaleDown string `json:"scaleDown"`
+}
+
 type Config struct {
-	FramesPath string `json:"framesPath"`
+	Version            int                 `json:"version"`
+	FramesPath         string              `json:"framesPath"`
+	AutoRestart        bool                `json:"autoRestart"`
+	PowerSaver         bool                `json:"powerSaver"`
+	TargetFPS          int                 `json:"targetFPS"`
+	SnapThreshold      int32               `json:"snapThreshold"`
+	DefaultAlwaysOnTop bool                `json:"defaultAlwaysOnTop"`
+	DefaultScale       float64             `json:"defaultScale"`
+	AnimatedScale      bool                `json:"animatedScale"`
+	ScaleAnchor        string              `json:"scaleAnchor"` // "center", "topLeft" or "bottomCenter"
+	DoubleClickMs      int                 `json:"doubleClickMs"`
+	KeyBindings        KeyBindings         `json:"keyBindings"`
+	WalkSpeed          float64             `json:"walkSpeed"`
+	IdleTimeoutSeconds int                 `json:"idleTimeoutSeconds"` // 0 disables the idle state
+	MaxWindows         int                 `json:"maxWindows"`
+	AutoRestoreSession bool                `json:"autoRestoreSession"`
+	AutoSpawn          []string            `json:"autoSpawn,omitempty"`
+	Favorites          []string            `json:"favorites,omitempty"`
+	RecentCharacters   []string            `json:"recentCharacters,omitempty"` // most recent first
+	RecentLimit        int                 `json:"recentLimit"`
+	LastPositions      map[string]Position `json:"lastPositions,omitempty"`
+	LastSession        []LayoutWindow      `json:"lastSession,omitempty"`
+}
+
+// Clone is needed before handing a config to another goroutine, such as
+// saving it outside a lock, because copying a Config by value still shares
+// its maps and slices.
+func (c Config) Clone() Config {
+	c.AutoSpawn = slices.Clone(c.AutoSpawn)
+	c.Favorites = slices.Clone(c.Favorites)
+	c.RecentCharacters = slices.Clone(c.RecentCharacters)
+	c.LastPositions = maps.Clone(c.LastPositions)
+	c.LastSession = slices.Clone(c.LastSession)
+	return c
 }
 
 func GetAppDataDir() string {
@@ -43,23 +112,43 @@ func getDefaultFramesPath() string {
 }
 
 func GetConfigPath() string {
-	return filepath.Join(GetAppDataDir(), "boccho.config.json")
+	return GetProfilePath(GetActiveProfile())
+}
+
+func getBackupPath(configPath string) string {
+	return configPath + ".bak"
 }
 
 func GetDefaultConfig() Config {
 	return Config{
-		FramesPath: getDefaultFramesPath(),
+		FramesPath:         getDefaultFramesPath(),
+		SnapThreshold:      DefaultSnapThreshold,
+		DefaultAlwaysOnTop: true,
+		DefaultScale:       DefaultScale,
+		ScaleAnchor:        DefaultScaleAnchor,
+		DoubleClickMs:      DefaultDoubleClickMs,
+		WalkSpeed:          DefaultWalkSpeed,
+		MaxWindows:         DefaultMaxWindows,
+		TargetFPS:          DefaultTargetFPS,
+		RecentLimit:        DefaultRecentLimit,
+		KeyBindings: KeyBindings{
+			Close:     "Escape",
+			ScaleUp:   "Up",
+			ScaleDown: "Down",
+		},
 	}
 }
 
 func LoadConfig() (Config, error) {
-	configPath := GetConfigPath()
+	return loadConfigFile(GetConfigPath())
+}
 
+func loadConfigFile(configPath string) (Config, error) {
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			cfg := GetDefaultConfig()
-			if saveErr := SaveConfig(cfg); saveErr != nil {
+			if saveErr := saveConfigFile(configPath, cfg); saveErr != nil {
 				fmt.Printf("Warning: Could not save default config: %v\n", saveErr)
 			}
 			return cfg, nil
@@ -67,7 +156,34 @@ func LoadConfig() (Config, error) {
 		return Config{}, err
 	}
 
-	var cfg Config
+	cfg, err := decodeConfig(data)
+	if err != nil {
+		backup, readErr := os.ReadFile(getBackupPath(configPath))
+		if readErr != nil {
+			return Config{}, err
+		}
+		recovered, decodeErr := decodeConfig(backup)
+		if decodeErr != nil {
+			return Config{}, err
+		}
+		// Not written back, so the broken file is still there to fix by hand.
+		fmt.Printf("Warning: Config is malformed (%v), loaded %s instead\n", err, getBackupPath(configPath))
+		return recovered, fmt.Errorf("%w: %v", ErrRecoveredFromBackup, err)
+	}
+
+	if migrateConfig(&cfg) {
+		if err := saveConfigFile(configPath, cfg); err != nil {
+			fmt.Printf("Warning: Could not save migrated config: %v\n", err)
+		}
+	}
+
+	return cfg, nil
+}
+
+func decodeConfig(data []byte) (Config, error) {
+	// Start from defaults so fields missing from older config files keep
+	// their default values instead of zero.
+	cfg := GetDefaultConfig()
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return Config{}, err
 	}
@@ -75,12 +191,17 @@ func LoadConfig() (Config, error) {
 	if cfg.FramesPath == "" {
 		cfg.FramesPath = getDefaultFramesPath()
 	}
-
 	return cfg, nil
 }
 
+// SaveConfig stamps the current schema version, except on files written by a
+// newer version which are saved as they are so their version isn't lowered.
 func SaveConfig(cfg Config) error {
-	configPath := GetConfigPath()
+	return saveConfigFile(GetConfigPath(), cfg)
+}
+
+func saveConfigFile(configPath string, cfg Config) error {
+	cfg.Version = max(cfg.Version, CurrentConfigVersion)
 
 	configDir := filepath.Dir(configPath)
 	if err := os.MkdirAll(configDir, 0755); err != nil {
@@ -92,7 +213,50 @@ func SaveConfig(cfg Config) error {
 		return err
 	}
 
-	return os.WriteFile(configPath, data, 0644)
+	// Keep the previous contents as a backup, unless they are malformed and
+	// would replace a good backup.
+	if previous, err := os.ReadFile(configPath); err == nil && json.Valid(previous) {
+		if err := writeFileAtomic(getBackupPath(configPath), previous); err != nil {
+			fmt.Printf("Warning: Could not back up config: %v\n", err)
+		}
+	}
+
+	if err := writeFileAtomic(configPath, data); err != nil {
+		return err
+	}
+	recordOwnWrite(configPath, data)
+	return nil
+}
+
+// writeFileAtomic writes to a temp file in the same directory and renames it
+// over path, so a crash mid-write leaves either the old or the new file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, 0644)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace %s: %w", filepath.Base(path), err)
+	}
+	return nil
 }
 
 func EnsureFramesDir(cfg Config) error {
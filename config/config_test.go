@@ -0,0 +1,96 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveConfigReplacesFileAndKeepsBackup(t *testing.T) {
+	path := writeTestConfig(t, `{"version": 1, "framesPath": "/old"}`)
+
+	cfg := GetDefaultConfig()
+	cfg.FramesPath = "/new"
+	if err := saveConfigFile(path, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadConfigFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.FramesPath != "/new" {
+		t.Errorf("saved framesPath %q, want /new", loaded.FramesPath)
+	}
+
+	backup, err := loadConfigFile(getBackupPath(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backup.FramesPath != "/old" {
+		t.Errorf("backup framesPath %q, want /old", backup.FramesPath)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".tmp") {
+			t.Errorf("temp file %s left behind", entry.Name())
+		}
+	}
+}
+
+func TestLoadConfigRecoversFromBackup(t *testing.T) {
+	const broken = `{"version": 1, "framesPath": "/new"`
+	path := writeTestConfig(t, broken)
+	if err := os.WriteFile(getBackupPath(path), []byte(`{"version": 1, "framesPath": "/old"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadConfigFile(path)
+	if !errors.Is(err, ErrRecoveredFromBackup) {
+		t.Fatalf("got %v, want ErrRecoveredFromBackup", err)
+	}
+	if cfg.FramesPath != "/old" {
+		t.Errorf("recovered framesPath %q, want /old", cfg.FramesPath)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != broken {
+		t.Error("recovery overwrote the malformed config")
+	}
+}
+
+func TestLoadConfigWithoutBackupFails(t *testing.T) {
+	path := writeTestConfig(t, `{"framesPath":`)
+
+	if _, err := loadConfigFile(path); err == nil || errors.Is(err, ErrRecoveredFromBackup) {
+		t.Fatalf("got %v, want the decode error", err)
+	}
+}
+
+func TestSaveConfigKeepsGoodBackup(t *testing.T) {
+	path := writeTestConfig(t, `{"framesPath":`)
+	if err := os.WriteFile(getBackupPath(path), []byte(`{"version": 1, "framesPath": "/old"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := saveConfigFile(path, GetDefaultConfig()); err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := loadConfigFile(getBackupPath(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backup.FramesPath != "/old" {
+		t.Errorf("malformed config replaced the backup; backup framesPath %q", backup.FramesPath)
+	}
+}
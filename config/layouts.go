@@ -0,0 +1,136 @@
+package config
+
+/*
+layouts.go - Named character layouts stored in the app data directory
+
+Each layout is saved as layouts/<name>.json and records the characters
+that were spawned together with their scale, window position and settings.
+Layouts saved before settings were recorded have no settings and restore
+windows with the defaults.
+
+Functions:
+- GetLayoutsDir: Returns the directory holding saved layouts
+- ValidateLayoutName: Reject names that are empty or contain path elements
+- SaveLayout: Write a layout to layouts/<name>.json
+- LoadLayout: Read a layout by name
+- ListLayouts: List saved layout names sorted alphabetically
+*/
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type LayoutWindow struct {
+	CharacterName string  `json:"characterName"`
+	Scale         float64 `json:"scale"`
+	X             int32   `json:"x"`
+	Y             int32   `json:"y"`
+	HasPosition   bool    `json:"hasPosition"`
+	// Settings is nil for windows that hadn't finished loading when saved.
+	Settings *WindowSettings `json:"settings,omitempty"`
+}
+
+// WindowSettings mirrors Window.WindowSettings, which config can't import.
+type WindowSettings struct {
+	State           string  `json:"state,omitempty"`
+	Flip            bool    `json:"flip"`
+	Opacity         float64 `json:"opacity"`
+	Speed           float64 `json:"speed"`
+	WindowOpacity   float32 `json:"windowOpacity"`
+	AlwaysOnTop     bool    `json:"alwaysOnTop"`
+	Gravity         bool    `json:"gravity"`
+	Behavior        string  `json:"behavior,omitempty"`
+	Locked          bool    `json:"locked"`
+	ClickThrough    bool    `json:"clickThrough"`
+	Tint            string  `json:"tint,omitempty"`
+	Reverse         bool    `json:"reverse"`
+	Paused          bool    `json:"paused"`
+	Background      string  `json:"background,omitempty"`
+	BackgroundAlpha uint8   `json:"backgroundAlpha"`
+	ScaleAnchor     string  `json:"scaleAnchor,omitempty"`
+	IdleState       string  `json:"idleState,omitempty"`
+}
+
+type Layout struct {
+	Name    string         `json:"name"`
+	Windows []LayoutWindow `json:"windows"`
+}
+
+func GetLayoutsDir() string {
+	return filepath.Join(GetAppDataDir(), "layouts")
+}
+
+func ValidateLayoutName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("layout name cannot be empty")
+	}
+	if strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return fmt.Errorf("invalid layout name %q", name)
+	}
+	return nil
+}
+
+func SaveLayout(layout Layout) error {
+	if err := ValidateLayoutName(layout.Name); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(GetLayoutsDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create layouts directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(layout, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(GetLayoutsDir(), layout.Name+".json"), data, 0644)
+}
+
+func LoadLayout(name string) (Layout, error) {
+	if err := ValidateLayoutName(name); err != nil {
+		return Layout{}, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(GetLayoutsDir(), name+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Layout{}, fmt.Errorf("layout %q not found", name)
+		}
+		return Layout{}, err
+	}
+
+	var layout Layout
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return Layout{}, fmt.Errorf("failed to parse layout %q: %w", name, err)
+	}
+	layout.Name = name
+
+	return layout, nil
+}
+
+func ListLayouts() ([]string, error) {
+	entries, err := os.ReadDir(GetLayoutsDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
@@ -0,0 +1,43 @@
+package config
+
+/*
+migrate.go - Config file schema versioning
+
+LoadConfig decodes on top of GetDefaultConfig, so fields missing from an
+older file already hold their defaults. Migrations only need to handle
+fields whose meaning changed between versions.
+
+Functions:
+- migrateConfig: Upgrade a loaded config to CurrentConfigVersion
+*/
+
+import "fmt"
+
+// CurrentConfigVersion is written by SaveConfig. Bump it and add a case to
+// migrateConfig when a field's meaning changes.
+const CurrentConfigVersion = 1
+
+// migrateConfig reports whether cfg changed and should be written back.
+// Files from a newer version are left as they are.
+func migrateConfig(cfg *Config) bool {
+	if cfg.Version > CurrentConfigVersion {
+		fmt.Printf("Warning: Config version %d is newer than supported version %d, unknown fields are ignored\n",
+			cfg.Version, CurrentConfigVersion)
+		return false
+	}
+	if cfg.Version == CurrentConfigVersion {
+		return false
+	}
+
+	for cfg.Version < CurrentConfigVersion {
+		switch cfg.Version {
+		case 0:
+			// Version-less files predate every field but framesPath; the
+			// defaults they were decoded onto are the migration.
+		}
+		cfg.Version++
+	}
+
+	fmt.Printf("Migrated config to version %d\n", cfg.Version)
+	return true
+}
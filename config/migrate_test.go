@@ -0,0 +1,79 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// writeTestConfig writes data as a config file in a temp directory and
+// keeps default paths out of the real home directory.
+func writeTestConfig(t *testing.T, data string) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("LOCALAPPDATA", home)
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func savedVersion(t *testing.T, path string) int {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	return saved.Version
+}
+
+func TestLoadMigratesVersionlessConfig(t *testing.T) {
+	path := writeTestConfig(t, `{"framesPath": "/frames", "snapThreshold": 30}`)
+
+	cfg, err := loadConfigFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Version != CurrentConfigVersion {
+		t.Errorf("loaded version %d, want %d", cfg.Version, CurrentConfigVersion)
+	}
+	if cfg.FramesPath != "/frames" || cfg.SnapThreshold != 30 {
+		t.Errorf("migration lost saved fields: framesPath %q, snapThreshold %d", cfg.FramesPath, cfg.SnapThreshold)
+	}
+	if cfg.DefaultScale != DefaultScale || cfg.MaxWindows != DefaultMaxWindows {
+		t.Errorf("missing fields not defaulted: defaultScale %v, maxWindows %d", cfg.DefaultScale, cfg.MaxWindows)
+	}
+	if got := savedVersion(t, path); got != CurrentConfigVersion {
+		t.Errorf("migrated config saved as version %d, want %d", got, CurrentConfigVersion)
+	}
+}
+
+func TestLoadKeepsNewerConfigVersion(t *testing.T) {
+	newer := CurrentConfigVersion + 1
+	path := writeTestConfig(t, `{"version": `+strconv.Itoa(newer)+`, "framesPath": "/frames"}`)
+
+	cfg, err := loadConfigFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Version != newer {
+		t.Errorf("loaded version %d, want %d", cfg.Version, newer)
+	}
+	if err := saveConfigFile(path, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if got := savedVersion(t, path); got != newer {
+		t.Errorf("saving lowered the version to %d", got)
+	}
+}
@@ -0,0 +1,144 @@
+package config
+
+/*
+profiles.go - Named config profiles stored next to boccho.config.json
+
+The default profile keeps using boccho.config.json so existing installs are
+unaffected. Other profiles are stored as boccho.<name>.config.json, and the
+name of the active one is kept in the active_profile file.
+
+Functions:
+- ValidateProfileName: Reject names that are empty or contain path elements
+- GetProfilePath: Returns the config file path of a profile
+- GetActiveProfile: Returns the name of the profile LoadConfig and SaveConfig use
+- SetActiveProfile: Switch the active profile and remember it across restarts
+- ListProfiles: List the default profile followed by saved profiles
+- LoadProfile: Load a profile's config, creating it with defaults if missing
+- SaveProfile: Save a config to a profile
+*/
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultProfile is the profile stored in boccho.config.json.
+const DefaultProfile = "default"
+
+const (
+	profilePrefix = "boccho."
+	profileSuffix = ".config.json"
+)
+
+var (
+	activeProfileMu     sync.Mutex
+	activeProfile       string
+	activeProfileLoaded bool
+)
+
+func getActiveProfilePath() string {
+	return filepath.Join(GetAppDataDir(), "active_profile")
+}
+
+func ValidateProfileName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("profile name cannot be empty")
+	}
+	if strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return fmt.Errorf("invalid profile name %q", name)
+	}
+	return nil
+}
+
+func GetProfilePath(name string) string {
+	if name == "" || name == DefaultProfile {
+		return filepath.Join(GetAppDataDir(), "boccho.config.json")
+	}
+	return filepath.Join(GetAppDataDir(), profilePrefix+name+profileSuffix)
+}
+
+// GetActiveProfile falls back to the default profile when the pointer file is
+// missing or names an invalid profile.
+func GetActiveProfile() string {
+	activeProfileMu.Lock()
+	defer activeProfileMu.Unlock()
+
+	if !activeProfileLoaded {
+		activeProfile = DefaultProfile
+		if data, err := os.ReadFile(getActiveProfilePath()); err == nil {
+			name := strings.TrimSpace(string(data))
+			if ValidateProfileName(name) == nil {
+				activeProfile = name
+			} else {
+				fmt.Printf("Warning: Ignoring invalid active profile %q\n", name)
+			}
+		}
+		activeProfileLoaded = true
+	}
+	return activeProfile
+}
+
+func SetActiveProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(GetAppDataDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := writeFileAtomic(getActiveProfilePath(), []byte(name+"\n")); err != nil {
+		return fmt.Errorf("failed to save active profile: %w", err)
+	}
+
+	activeProfileMu.Lock()
+	activeProfile = name
+	activeProfileLoaded = true
+	activeProfileMu.Unlock()
+	return nil
+}
+
+func ListProfiles() ([]string, error) {
+	entries, err := os.ReadDir(GetAppDataDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{DefaultProfile}, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		fileName := entry.Name()
+		// The length check skips boccho.config.json, where prefix and suffix overlap.
+		if entry.IsDir() || len(fileName) <= len(profilePrefix)+len(profileSuffix) ||
+			!strings.HasPrefix(fileName, profilePrefix) || !strings.HasSuffix(fileName, profileSuffix) {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(fileName, profilePrefix), profileSuffix)
+		if name == DefaultProfile || ValidateProfileName(name) != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return append([]string{DefaultProfile}, names...), nil
+}
+
+func LoadProfile(name string) (Config, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return Config{}, err
+	}
+	return loadConfigFile(GetProfilePath(name))
+}
+
+func SaveProfile(name string, cfg Config) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	return saveConfigFile(GetProfilePath(name), cfg)
+}
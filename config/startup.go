@@ -0,0 +1,55 @@
+package config
+
+/*
+startup.go - Launch the app when the user logs in
+
+The platform files register the current executable: a value under the
+per-user Run registry key on Windows (startup_windows.go), a LaunchAgent
+plist on macOS (startup_darwin.go) and an XDG autostart .desktop file on
+Linux (startup_linux.go). Other platforms return ErrStartupUnsupported.
+
+Functions:
+- SetLaunchOnStartup: Register or unregister the app for launch at login
+- IsLaunchOnStartup: Check if the app is registered for launch at login
+- startupExecutable: Resolve the path of the running executable
+*/
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// startupName identifies the app's entry in the OS startup list.
+const startupName = "boccho-ui"
+
+var ErrStartupUnsupported = errors.New("launch on startup is not supported on this platform")
+
+// SetLaunchOnStartup registers the executable that is running now, so a
+// moved or reinstalled app needs to be registered again.
+func SetLaunchOnStartup(enabled bool) error {
+	if enabled {
+		exe, err := startupExecutable()
+		if err != nil {
+			return err
+		}
+		return enableLaunchOnStartup(exe)
+	}
+	return disableLaunchOnStartup()
+}
+
+func IsLaunchOnStartup() (bool, error) {
+	return isLaunchOnStartup()
+}
+
+func startupExecutable() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the app executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	return exe, nil
+}
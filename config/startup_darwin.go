@@ -0,0 +1,93 @@
+package config
+
+/*
+startup_darwin.go - Launch at login through a LaunchAgent
+
+The plist is picked up by launchd at the next login; it is not loaded into
+the current session.
+
+Functions:
+- getLaunchAgentPath: Returns the path of the app's LaunchAgent plist
+- enableLaunchOnStartup: Write the LaunchAgent plist for the executable
+- disableLaunchOnStartup: Remove the LaunchAgent plist
+- isLaunchOnStartup: Check if the LaunchAgent plist exists
+*/
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const launchAgentLabel = "com." + startupName
+
+func getLaunchAgentPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(homeDir, "Library", "LaunchAgents", launchAgentLabel+".plist"), nil
+}
+
+func enableLaunchOnStartup(exe string) error {
+	plistPath, err := getLaunchAgentPath()
+	if err != nil {
+		return err
+	}
+
+	var escaped bytes.Buffer
+	if err := xml.EscapeText(&escaped, []byte(exe)); err != nil {
+		return err
+	}
+
+	plist := `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>` + launchAgentLabel + `</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>` + escaped.String() + `</string>
+	</array>
+	<key>RunAtLoad</key>
+	<true/>
+</dict>
+</plist>
+`
+
+	if err := os.MkdirAll(filepath.Dir(plistPath), 0755); err != nil {
+		return fmt.Errorf("failed to create LaunchAgents directory: %w", err)
+	}
+	if err := os.WriteFile(plistPath, []byte(plist), 0644); err != nil {
+		return fmt.Errorf("failed to write LaunchAgent: %w", err)
+	}
+	return nil
+}
+
+func disableLaunchOnStartup() error {
+	plistPath, err := getLaunchAgentPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(plistPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove LaunchAgent: %w", err)
+	}
+	return nil
+}
+
+func isLaunchOnStartup() (bool, error) {
+	plistPath, err := getLaunchAgentPath()
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(plistPath); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,84 @@
+package config
+
+/*
+startup_linux.go - Launch at login through an XDG autostart entry
+
+Functions:
+- getAutostartPath: Returns the path of the app's autostart .desktop file
+- desktopExecQuote: Quote a path for the Exec key of a .desktop file
+- enableLaunchOnStartup: Write the autostart entry for the executable
+- disableLaunchOnStartup: Remove the autostart entry
+- isLaunchOnStartup: Check if the autostart entry exists
+*/
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func getAutostartPath() (string, error) {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to find home directory: %w", err)
+		}
+		configDir = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configDir, "autostart", startupName+".desktop"), nil
+}
+
+// desktopExecQuote follows the Desktop Entry spec: the argument is wrapped
+// in double quotes with ", `, $ and \ backslash-escaped.
+func desktopExecQuote(path string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", `$`, `\$`)
+	return `"` + replacer.Replace(path) + `"`
+}
+
+func enableLaunchOnStartup(exe string) error {
+	desktopPath, err := getAutostartPath()
+	if err != nil {
+		return err
+	}
+
+	entry := "[Desktop Entry]\n" +
+		"Type=Application\n" +
+		"Name=Boccho\n" +
+		"Exec=" + desktopExecQuote(exe) + "\n" +
+		"X-GNOME-Autostart-enabled=true\n"
+
+	if err := os.MkdirAll(filepath.Dir(desktopPath), 0755); err != nil {
+		return fmt.Errorf("failed to create autostart directory: %w", err)
+	}
+	if err := os.WriteFile(desktopPath, []byte(entry), 0644); err != nil {
+		return fmt.Errorf("failed to write autostart entry: %w", err)
+	}
+	return nil
+}
+
+func disableLaunchOnStartup() error {
+	desktopPath, err := getAutostartPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(desktopPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove autostart entry: %w", err)
+	}
+	return nil
+}
+
+func isLaunchOnStartup() (bool, error) {
+	desktopPath, err := getAutostartPath()
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(desktopPath); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,22 @@
+//go:build !windows && !darwin && !linux
+
+package config
+
+/*
+startup_other.go - Launch on startup is unavailable on this platform
+
+Functions:
+- enableLaunchOnStartup / disableLaunchOnStartup / isLaunchOnStartup: Return ErrStartupUnsupported
+*/
+
+func enableLaunchOnStartup(exe string) error {
+	return ErrStartupUnsupported
+}
+
+func disableLaunchOnStartup() error {
+	return ErrStartupUnsupported
+}
+
+func isLaunchOnStartup() (bool, error) {
+	return false, ErrStartupUnsupported
+}
@@ -0,0 +1,69 @@
+package config
+
+/*
+startup_windows.go - Launch at login through the per-user Run registry key
+
+Functions:
+- enableLaunchOnStartup: Write the Run value for the executable
+- disableLaunchOnStartup: Delete the Run value
+- isLaunchOnStartup: Check if the Run value exists
+*/
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+const runKeyPath = `Software\Microsoft\Windows\CurrentVersion\Run`
+
+func enableLaunchOnStartup(exe string) error {
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, runKeyPath, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("failed to open Run registry key: %w", err)
+	}
+	defer key.Close()
+
+	// Quoted so paths with spaces start the right program.
+	if err := key.SetStringValue(startupName, `"`+exe+`"`); err != nil {
+		return fmt.Errorf("failed to write Run registry value: %w", err)
+	}
+	return nil
+}
+
+func disableLaunchOnStartup() error {
+	key, err := registry.OpenKey(registry.CURRENT_USER, runKeyPath, registry.SET_VALUE)
+	if errors.Is(err, registry.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open Run registry key: %w", err)
+	}
+	defer key.Close()
+
+	if err := key.DeleteValue(startupName); err != nil && !errors.Is(err, registry.ErrNotExist) {
+		return fmt.Errorf("failed to delete Run registry value: %w", err)
+	}
+	return nil
+}
+
+func isLaunchOnStartup() (bool, error) {
+	key, err := registry.OpenKey(registry.CURRENT_USER, runKeyPath, registry.QUERY_VALUE)
+	if errors.Is(err, registry.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to open Run registry key: %w", err)
+	}
+	defer key.Close()
+
+	_, _, err = key.GetStringValue(startupName)
+	if errors.Is(err, registry.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read Run registry value: %w", err)
+	}
+	return true, nil
+}
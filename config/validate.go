@@ -0,0 +1,67 @@
+package config
+
+/*
+validate.go - Sanity checks for hand-edited config files
+
+Functions:
+- (Config) Validate: Check the config for values the app cannot work with
+- checkWritableDir: Check that a directory exists or can be created, and is writable
+*/
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Validate returns a descriptive error for every problem found, joined into
+// one error, or nil when the config is usable.
+func (cfg Config) Validate() error {
+	var errs []error
+
+	switch {
+	case cfg.FramesPath == "":
+		errs = append(errs, errors.New("framesPath is empty"))
+	case !filepath.IsAbs(cfg.FramesPath):
+		errs = append(errs, fmt.Errorf("framesPath %q is not an absolute path", cfg.FramesPath))
+	default:
+		if err := checkWritableDir(cfg.FramesPath); err != nil {
+			errs = append(errs, fmt.Errorf("framesPath %q is not usable: %w", cfg.FramesPath, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkWritableDir walks up to the nearest existing ancestor when dir doesn't
+// exist yet, since EnsureFramesDir will create the rest.
+func checkWritableDir(dir string) error {
+	existing := dir
+	for {
+		info, err := os.Stat(existing)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%s is a file, not a directory", existing)
+			}
+			break
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return fmt.Errorf("no existing parent directory")
+		}
+		existing = parent
+	}
+
+	f, err := os.CreateTemp(existing, ".boccho-write-test-*")
+	if err != nil {
+		return fmt.Errorf("directory is not writable: %w", err)
+	}
+	name := f.Name()
+	f.Close()
+	os.Remove(name)
+	return nil
+}
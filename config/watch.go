@@ -0,0 +1,133 @@
+package config
+
+/*
+watch.go - Reload notifications when boccho.config.json is edited on disk
+
+The config directory is watched rather than the file itself because most
+editors save by writing a temp file and renaming it over the original,
+which would drop a watch on the old inode.
+
+Writes made by the app itself through SaveConfig are recorded by content
+hash and don't trigger a reload, so saving a favorite or a window position
+doesn't reload the config it was just saved from.
+
+Functions:
+- WatchConfig: Call onChange after the config file settles following a write
+- recordOwnWrite: Remember the contents the app last wrote to a config file
+- isOwnWrite: Check if a config file still holds what the app last wrote
+*/
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// configDebounce groups the several events an editor save produces into one reload.
+const configDebounce = 300 * time.Millisecond
+
+var (
+	ownWritesMu sync.Mutex
+	ownWrites   = map[string][sha256.Size]byte{}
+)
+
+func recordOwnWrite(configPath string, data []byte) {
+	ownWritesMu.Lock()
+	defer ownWritesMu.Unlock()
+	ownWrites[filepath.Clean(configPath)] = sha256.Sum256(data)
+}
+
+// isOwnWrite compares contents rather than modification times, which are too
+// coarse on some file systems to tell a save from an edit right after it.
+func isOwnWrite(configPath string) bool {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return false
+	}
+
+	ownWritesMu.Lock()
+	defer ownWritesMu.Unlock()
+	sum, ok := ownWrites[filepath.Clean(configPath)]
+	return ok && sum == sha256.Sum256(data)
+}
+
+// WatchConfig runs until ctx is done. onChange is called from the watcher
+// goroutine and is not called while the file is missing.
+func WatchConfig(ctx context.Context, onChange func()) error {
+	configDir := filepath.Dir(GetConfigPath())
+
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create config watcher: %w", err)
+	}
+	if err := watcher.Add(configDir); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch config directory: %w", err)
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		var debounce *time.Timer
+		fire := make(chan struct{}, 1)
+
+		for {
+			select {
+			case <-ctx.Done():
+				if debounce != nil {
+					debounce.Stop()
+				}
+				return
+
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// Looked up per event so switching profiles follows the new file.
+				if filepath.Clean(event.Name) != GetConfigPath() {
+					continue
+				}
+				if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+					continue
+				}
+				if debounce != nil {
+					debounce.Stop()
+				}
+				debounce = time.AfterFunc(configDebounce, func() {
+					select {
+					case fire <- struct{}{}:
+					default:
+					}
+				})
+
+			case <-fire:
+				if _, err := os.Stat(GetConfigPath()); err != nil {
+					// Deleted or mid-rename; the next Create event reloads it.
+					continue
+				}
+				if isOwnWrite(GetConfigPath()) {
+					continue
+				}
+				onChange()
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				fmt.Printf("Config watcher error: %v\n", err)
+			}
+		}
+	}()
+
+	return nil
+}
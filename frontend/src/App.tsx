@@ -4,13 +4,20 @@ App.tsx - Main character manager UI
 Components:
 - App: Main application component with character grid and active windows list
 - AnimatedPreview: Component that cycles through frames for animation preview
-- AddDropdown: Dropdown menu for adding packs (from Link or .bfk)
+- AddDropdown: Dropdown menu for adding packs (from Link or .bfk/.zip)
 - AddPackModal: Modal for confirming pack installation with preview
 */
 
 import { useState, useEffect, useCallback, useRef } from 'react';
 import './App.css';
-import { CharacterInfo, CharacterWindowInfo, PackInfo } from './types';
+import {
+  CharacterInfo,
+  CharacterWindowInfo,
+  PackEntry,
+  PackInfo,
+  PackProgress,
+  WindowFailureInfo,
+} from './types';
 import {
   GetCharacters,
   SpawnCharacter,
@@ -20,18 +27,32 @@ import {
   GetPreviewFrames,
   OpenFramesDir,
   OpenConfig,
-  BrowseBfkFile,
+  BrowseBfkFiles,
+  InstallBfkPacks,
+  GetBfkPackContents,
   GetBfkPackInfo,
-  InstallBfkPack,
+  InstallBfkPackWithProgress,
+  CancelInstall,
+  InstallBfkFromURL,
+  HideAllCharacters,
+  ShowAllCharacters,
+  GetConfigError,
+  DeleteCharacter,
+  RenameCharacter,
+  ToggleFavorite,
+  GetRecentCharacters,
+  BrowseFramesDir,
+  SetFramesPath,
 } from '../wailsjs/go/main/App';
+import { EventsOn } from '../wailsjs/runtime/runtime';
 
 import linkIcon from './assets/images/link.svg';
 import plusIcon from './assets/images/Plus_button.svg';
 
 const MAX_PREVIEW_FRAMES = 16;
-const ANIMATION_FPS = 12;
+const DEFAULT_ANIMATION_FPS = 12;
 
-function AnimatedPreview({ characterName }: { characterName: string }) {
+function AnimatedPreview({ characterName, fps }: { characterName: string; fps?: number }) {
   const [frames, setFrames] = useState<string[]>([]);
   const [currentFrame, setCurrentFrame] = useState(0);
   const [isHovered, setIsHovered] = useState(false);
@@ -47,7 +68,7 @@ function AnimatedPreview({ characterName }: { characterName: string }) {
     if (isHovered && frames.length > 1) {
       intervalRef.current = window.setInterval(() => {
         setCurrentFrame((prev) => (prev + 1) % frames.length);
-      }, 1000 / ANIMATION_FPS);
+      }, 1000 / (fps || DEFAULT_ANIMATION_FPS));
     } else {
       if (intervalRef.current) {
         clearInterval(intervalRef.current);
@@ -61,7 +82,7 @@ function AnimatedPreview({ characterName }: { characterName: string }) {
         clearInterval(intervalRef.current);
       }
     };
-  }, [isHovered, frames.length]);
+  }, [isHovered, frames.length, fps]);
 
   if (frames.length === 0) {
     return (
@@ -133,7 +154,7 @@ function AddDropdown({ onAddFromFile, onAddFromLink }: AddDropdownProps) {
             <div className="add-dropdown-icon">
               <img src={plusIcon} alt="" />
             </div>
-            <span>From .bfk</span>
+            <span>From .bfk / .zip</span>
           </button>
         </div>
       )}
@@ -143,24 +164,58 @@ function AddDropdown({ onAddFromFile, onAddFromLink }: AddDropdownProps) {
 
 interface AddPackModalProps {
   packInfo: PackInfo;
-  onInstall: () => void;
+  onInstall: (overwrite: boolean) => void;
   onCancel: () => void;
   installing: boolean;
+  progress: PackProgress | null;
 }
 
-function AddPackModal({ packInfo, onInstall, onCancel, installing }: AddPackModalProps) {
+function AddPackModal({ packInfo, onInstall, onCancel, installing, progress }: AddPackModalProps) {
+  const [overwrite, setOverwrite] = useState(false);
+  const [contents, setContents] = useState<PackEntry[] | null>(null);
+  const conflicts = packInfo.conflicts || [];
+
+  const handleToggleContents = (open: boolean) => {
+    if (open && contents === null) {
+      GetBfkPackContents(packInfo.filePath).then((entries) => setContents(entries || []));
+    }
+  };
+
   return (
-    <div className="modal-overlay" onClick={onCancel}>
+    <div className="modal-overlay" onClick={installing ? undefined : onCancel}>
       <div className="modal-content" onClick={(e) => e.stopPropagation()}>
         <div className="modal-header">
           <h3 className="modal-title">Install Pack</h3>
-          <p className="modal-subtitle">{packInfo.packName}</p>
+          <p className="modal-subtitle">
+            {packInfo.packName}
+            {packInfo.version && ` v${packInfo.version}`}
+            {packInfo.author && ` by ${packInfo.author}`}
+          </p>
         </div>
 
-        {packInfo.previewImage && (
-          <div className="modal-preview">
-            <img src={packInfo.previewImage} alt="Preview" />
+        {packInfo.characters?.length > 1 ? (
+          <div className="modal-preview-grid">
+            {packInfo.characters.map((name) => (
+              <div key={name} className="modal-preview-item">
+                {packInfo.characterPreviews?.[name] ? (
+                  <img src={packInfo.characterPreviews[name]} alt={name} />
+                ) : (
+                  <div className="preview-placeholder">{name.charAt(0).toUpperCase()}</div>
+                )}
+                <span>{name}</span>
+              </div>
+            ))}
           </div>
+        ) : (
+          packInfo.previewImage && (
+            <div className="modal-preview">
+              <img src={packInfo.previewImage} alt="Preview" />
+            </div>
+          )
+        )}
+
+        {packInfo.description && (
+          <p className="modal-info">{packInfo.description}</p>
         )}
 
         {packInfo.error ? (
@@ -171,13 +226,57 @@ function AddPackModal({ packInfo, onInstall, onCancel, installing }: AddPackModa
           </p>
         )}
 
+        {!packInfo.error && conflicts.length > 0 && (
+          <div className="modal-conflicts">
+            <p className="modal-error">
+              Already installed: <span>{conflicts.join(', ')}</span>
+            </p>
+            <label className="modal-option">
+              <input
+                type="checkbox"
+                checked={overwrite}
+                onChange={(e) => setOverwrite(e.target.checked)}
+                disabled={installing}
+              />
+              Overwrite installed characters (otherwise they are skipped)
+            </label>
+          </div>
+        )}
+
+        {!packInfo.error && (
+          <details
+            className="modal-contents"
+            onToggle={(e) => handleToggleContents((e.target as HTMLDetailsElement).open)}
+          >
+            <summary>Show files</summary>
+            {contents === null ? (
+              <p className="hint">Loading...</p>
+            ) : (
+              <ul>
+                {contents
+                  .filter((entry) => !entry.isDir)
+                  .map((entry) => (
+                    <li key={entry.name} className={entry.isFrame ? '' : 'not-frame'}>
+                      <span>{entry.name}</span>
+                      <span>{(entry.size / 1024).toFixed(1)} KB</span>
+                    </li>
+                  ))}
+              </ul>
+            )}
+          </details>
+        )}
+
         <div className="modal-actions">
-          <button className="btn btn-cancel" onClick={onCancel} disabled={installing}>
-            Cancel
+          <button className="btn btn-cancel" onClick={onCancel}>
+            {installing ? 'Stop' : 'Cancel'}
           </button>
           {!packInfo.error && (
-            <button className="btn btn-install" onClick={onInstall} disabled={installing}>
-              {installing ? 'Installing...' : 'Install'}
+            <button className="btn btn-install" onClick={() => onInstall(overwrite)} disabled={installing}>
+              {installing
+                ? progress && progress.total > 0
+                  ? `Installing... ${Math.floor((progress.done * 100) / progress.total)}%`
+                  : 'Installing...'
+                : 'Install'}
             </button>
           )}
         </div>
@@ -192,12 +291,19 @@ function App() {
   const [loading, setLoading] = useState(true);
   const [packInfo, setPackInfo] = useState<PackInfo | null>(null);
   const [installing, setInstalling] = useState(false);
+  const [installProgress, setInstallProgress] = useState<PackProgress | null>(null);
+  const [installId, setInstallId] = useState('');
+  const [configError, setConfigError] = useState('');
+  const [recentCharacters, setRecentCharacters] = useState<string[]>([]);
 
   const loadCharacters = useCallback(async () => {
     setLoading(true);
     try {
-      const chars = await GetCharacters();
-      setCharacters(chars || []);
+      const chars = (await GetCharacters()) || [];
+      // Favorites are pinned to the top; sort is stable so the rest keep their order.
+      chars.sort((a, b) => Number(b.isFavorite) - Number(a.isFavorite));
+      setCharacters(chars);
+      setRecentCharacters((await GetRecentCharacters()) || []);
     } catch (err) {
       console.error('Failed to load characters:', err);
     }
@@ -215,19 +321,95 @@ function App() {
 
   useEffect(() => {
     loadCharacters();
+    GetConfigError().then(setConfigError);
     const interval = setInterval(refreshActiveWindows, 1000);
-    return () => clearInterval(interval);
+    const offClosed = EventsOn('window:closed', (windowId: string) => {
+      setActiveWindows((prev) => prev.filter((w) => w.id !== windowId));
+    });
+    const offFailed = EventsOn('window:failed', (info: WindowFailureInfo) => {
+      console.error(`Window of ${info.characterName} failed to start: ${info.reason}`);
+      setActiveWindows((prev) => prev.filter((w) => w.id !== info.windowId));
+    });
+    const offReloaded = EventsOn('config:reloaded', () => {
+      GetConfigError().then(setConfigError);
+      loadCharacters();
+    });
+    const offProgress = EventsOn('pack:progress', (progress: PackProgress) => {
+      setInstallProgress(progress);
+    });
+    const offDone = EventsOn('pack:done', () => {
+      setInstalling(false);
+      setInstallProgress(null);
+      setPackInfo(null);
+      loadCharacters();
+    });
+    const offError = EventsOn('pack:error', (progress: PackProgress) => {
+      setInstalling(false);
+      setInstallProgress(null);
+      if (!progress.cancelled) {
+        alert(`Could not install pack: ${progress.error}`);
+      }
+    });
+    return () => {
+      clearInterval(interval);
+      offClosed();
+      offFailed();
+      offReloaded();
+      offProgress();
+      offDone();
+      offError();
+    };
   }, [loadCharacters, refreshActiveWindows]);
 
   const handleSpawn = async (characterName: string) => {
     try {
-      await SpawnCharacter(characterName);
+      const info = await SpawnCharacter(characterName);
+      if (info.error) {
+        alert(`Could not spawn ${characterName}: ${info.error}`);
+      }
       refreshActiveWindows();
+      setRecentCharacters((await GetRecentCharacters()) || []);
     } catch (err) {
       console.error('Failed to spawn character:', err);
     }
   };
 
+  const handleDelete = async (characterName: string) => {
+    if (!confirm(`Delete ${characterName} from the Frames directory? Open windows of it will be closed.`)) {
+      return;
+    }
+    try {
+      await DeleteCharacter(characterName);
+      loadCharacters();
+      refreshActiveWindows();
+    } catch (err) {
+      alert(`Could not delete ${characterName}: ${err}`);
+    }
+  };
+
+  const handleRename = async (characterName: string) => {
+    const newName = prompt(`Rename ${characterName} to:`, characterName)?.trim();
+    if (!newName || newName === characterName) {
+      return;
+    }
+    try {
+      await RenameCharacter(characterName, newName);
+      loadCharacters();
+      refreshActiveWindows();
+    } catch (err) {
+      alert(`Could not rename ${characterName}: ${err}`);
+    }
+  };
+
+  const handleToggleFavorite = async (characterName: string) => {
+    try {
+      await ToggleFavorite(characterName);
+      loadCharacters();
+    } catch (err) {
+      console.error('Failed to toggle favorite:', err);
+    }
+  };
+
   const handleDestroy = async (windowId: string) => {
     try {
       await DestroyCharacter(windowId);
@@ -245,6 +427,21 @@ function App() {
     }
   };
 
+  const allHidden = activeWindows.length > 0 && activeWindows.every((w) => !w.visible);
+
+  const handleToggleAllVisible = async () => {
+    try {
+      if (allHidden) {
+        await ShowAllCharacters();
+      } else {
+        await HideAllCharacters();
+      }
+      setTimeout(refreshActiveWindows, 100);
+    } catch (err) {
+      console.error('Failed to toggle visibility:', err);
+    }
+  };
+
   const handleOpenFrames = async () => {
     try {
       await OpenFramesDir();
@@ -253,6 +450,19 @@ function App() {
     }
   };
 
+  const handleChangeFramesDir = async () => {
+    const dir = await BrowseFramesDir();
+    if (!dir) {
+      return;
+    }
+    try {
+      await SetFramesPath(dir);
+      loadCharacters();
+    } catch (err) {
+      alert(`Could not use ${dir} as the Frames directory: ${err}`);
+    }
+  };
+
   const handleOpenConfig = async () => {
     try {
       await OpenConfig();
@@ -263,36 +473,66 @@ function App() {
 
   const handleAddFromFile = async () => {
     try {
-      const filePath = await BrowseBfkFile();
-      if (!filePath) return;
+      const filePaths = (await BrowseBfkFiles()) || [];
+      if (filePaths.length === 0) return;
+
+      if (filePaths.length === 1) {
+        const info = await GetBfkPackInfo(filePaths[0]);
+        setPackInfo(info);
+        return;
+      }
 
-      const info = await GetBfkPackInfo(filePath);
-      setPackInfo(info);
+      if (!confirm(`Install ${filePaths.length} packs? Installed characters will be overwritten.`)) {
+        return;
+      }
+      const results = (await InstallBfkPacks(filePaths)) || [];
+      const failed = results.filter((r) => !r.success);
+      loadCharacters();
+      if (failed.length > 0) {
+        alert(
+          `Installed ${results.length - failed.length} of ${results.length} packs.\n\n` +
+            failed.map((r) => `${r.packName || r.filePath}: ${r.error}`).join('\n'),
+        );
+      }
     } catch (err) {
       console.error('Failed to browse pack:', err);
     }
   };
 
-  const handleAddFromLink = () => {
-    // TODO: Implement add from link
-    console.log('Add from link - not implemented yet');
+  const handleAddFromLink = async () => {
+    const url = prompt('Pack link (https://...):')?.trim();
+    if (!url) return;
+
+    try {
+      await InstallBfkFromURL(url);
+      loadCharacters();
+    } catch (err) {
+      alert(`Could not install pack from link: ${err}`);
+    }
   };
 
-  const handleInstallPack = async () => {
+  const handleInstallPack = async (overwrite: boolean) => {
     if (!packInfo) return;
 
     setInstalling(true);
+    setInstallProgress(null);
     try {
-      await InstallBfkPack(packInfo.filePath);
-      setPackInfo(null);
-      loadCharacters();
+      // Completion is reported through the pack:done and pack:error events.
+      setInstallId(await InstallBfkPackWithProgress(packInfo.filePath, overwrite));
     } catch (err) {
       console.error('Failed to install pack:', err);
+      setInstalling(false);
     }
-    setInstalling(false);
   };
 
   const handleCancelPack = () => {
+    if (installing) {
+      // The modal stays open until pack:error confirms the rollback finished.
+      if (installId) {
+        CancelInstall(installId);
+      }
+      return;
+    }
     setPackInfo(null);
   };
 
@@ -308,15 +548,34 @@ function App() {
             onAddFromFile={handleAddFromFile}
             onAddFromLink={handleAddFromLink}
           />
+          <button
+            className="btn btn-toolbar"
+            onClick={handleToggleAllVisible}
+            disabled={activeWindows.length === 0}
+          >
+            {allHidden ? 'Show All' : 'Hide All'}
+          </button>
           <button className="btn btn-toolbar" onClick={handleOpenFrames}>
             Open Frames Dir
           </button>
+          <button className="btn btn-toolbar" onClick={handleChangeFramesDir}>
+            Change Frames Dir
+          </button>
           <button className="btn btn-toolbar" onClick={handleOpenConfig}>
             Open Config
           </button>
         </div>
       </header>
 
+      {configError && (
+        <div className="config-error">
+          <span>{configError}</span>
+          <button className="btn btn-toolbar" onClick={handleOpenConfig}>
+            Open Config
+          </button>
+        </div>
+      )}
+
       <main className="app-content">
         <section className="section">
           <div className="section-header">
@@ -325,6 +584,20 @@ function App() {
               Refresh
             </button>
           </div>
+          {recentCharacters.length > 0 && (
+            <div className="recent-row">
+              <span className="recent-label">Recent</span>
+              {recentCharacters.map((name) => (
+                <button
+                  key={name}
+                  className="btn btn-toolbar"
+                  onClick={() => handleSpawn(name)}
+                >
+                  {characters.find((c) => c.name === name)?.displayName || name}
+                </button>
+              ))}
+            </div>
+          )}
           {loading ? (
             <div className="loading">Loading characters...</div>
           ) : characters.length === 0 ? (
@@ -337,20 +610,56 @@ function App() {
           ) : (
             <div className="character-grid">
               {characters.map((char) => (
-                <div key={char.name} className="character-card">
+                <div
+                  key={char.name}
+                  className={`character-card${char.valid ? '' : ' invalid'}`}
+                >
                   <div className="character-preview">
-                    <AnimatedPreview characterName={char.name} />
+                    <button
+                      className={`favorite-toggle${char.isFavorite ? ' active' : ''}`}
+                      title={char.isFavorite ? 'Remove from favorites' : 'Add to favorites'}
+                      onClick={() => handleToggleFavorite(char.name)}
+                    >
+                      {char.isFavorite ? '★' : '☆'}
+                    </button>
+                    <AnimatedPreview characterName={char.name} fps={char.fps} />
                   </div>
                   <div className="character-info">
-                    <span className="character-name">{char.name}</span>
-                    <span className="frame-count">{char.frameCount} frames</span>
+                    <span
+                      className="character-name"
+                      title={[char.description, char.author && `by ${char.author}`]
+                        .filter(Boolean)
+                        .join('\n')}
+                    >
+                      {char.displayName || char.name}
+                    </span>
+                    <span className="frame-count">
+                      {char.frameCount} frames
+                      {char.states && char.states.length > 0 && ` · ${char.states.length} states`}
+                    </span>
+                    {!char.valid && (
+                      <span className="character-invalid">Frames can't be loaded</span>
+                    )}
                   </div>
                   <button
                     className="btn btn-spawn"
                     onClick={() => handleSpawn(char.name)}
+                    disabled={!char.valid}
                   >
                     Spawn
                   </button>
+                  <button
+                    className="btn btn-toolbar"
+                    onClick={() => handleRename(char.name)}
+                  >
+                    Rename
+                  </button>
+                  <button
+                    className="btn btn-destroy"
+                    onClick={() => handleDelete(char.name)}
+                  >
+                    Delete
+                  </button>
                 </div>
               ))}
             </div>
@@ -406,7 +715,7 @@ function App() {
       </main>
 
       <footer className="app-footer">
-        <p>Arrow Keys: Resize | Escape: Close Window | Drag: Move</p>
+        <p>Arrow Keys: Resize | Double-click: Reset Size | Escape: Close Window | Drag: Move</p>
       </footer>
 
       {packInfo && (
@@ -415,6 +724,7 @@ function App() {
           onInstall={handleInstallPack}
           onCancel={handleCancelPack}
           installing={installing}
+          progress={installProgress}
         />
       )}
     </div>
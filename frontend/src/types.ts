@@ -4,7 +4,17 @@ types.ts - TypeScript interfaces for Wails bindings
 Interfaces:
 - CharacterInfo: Character metadata from Go backend
 - CharacterWindowInfo: Active window information with scale
+- CharacterPosition: Screen position of an active window
+- ContextMenuInfo: Payload of the character:contextmenu event
+- WindowFailureInfo: Payload of the window:failed event
+- WindowStats: Measured render statistics of an active window
+- RenderInfo: Current frame and scaled size of an active window
+- DisplayInfo: Connected monitor with its desktop bounds
 - PackInfo: Pack metadata for installation preview
+- PackProgress: Payload of the pack:progress, pack:done and pack:error events
+- PackInstallStatus: Per-character install state of a pack
+- PackInstallResult: Outcome of one pack in a batch install
+- PackEntry: One file listed by GetBfkPackContents
 */
 
 export interface CharacterInfo {
@@ -12,6 +22,13 @@ export interface CharacterInfo {
   path: string;
   previewPath: string;
   frameCount: number;
+  fps: number;
+  states?: string[];
+  displayName: string;
+  author?: string;
+  description?: string;
+  isFavorite: boolean;
+  valid: boolean;
 }
 
 export interface CharacterWindowInfo {
@@ -19,12 +36,104 @@ export interface CharacterWindowInfo {
   characterName: string;
   isRunning: boolean;
   scale: number;
+  visible: boolean;
+  error?: string;
+}
+
+export interface CharacterPosition {
+  x: number;
+  y: number;
+  ok: boolean;
+}
+
+export interface ContextMenuInfo {
+  windowId: string;
+  characterName: string;
+  x: number;
+  y: number;
+}
+
+export interface WindowFailureInfo {
+  windowId: string;
+  characterName: string;
+  reason: string;
+}
+
+export interface WindowStats {
+  fps: number;
+  frameCount: number;
+  textureCount: number;
+  vramBytes: number;
+  renderer: string;
+}
+
+export interface RenderInfo {
+  frameIndex: number;
+  frameCount: number;
+  width: number;
+  height: number;
+  scale: number;
+}
+
+export interface DisplayInfo {
+  index: number;
+  name: string;
+  x: number;
+  y: number;
+  width: number;
+  height: number;
 }
 
 export interface PackInfo {
   filePath: string;
   packName: string;
   characters: string[];
+  frameCounts: Record<string, number>;
   previewImage: string;
+  characterPreviews: Record<string, string>;
+  author?: string;
+  version?: string;
+  description?: string;
+  conflicts?: string[];
+  error?: string;
+}
+
+export interface PackProgress {
+  installId: string;
+  filePath: string;
+  done: number;
+  total: number;
+  error?: string;
+  cancelled?: boolean;
+}
+
+export interface PackEntry {
+  name: string;
+  size: number;
+  isDir: boolean;
+  isFrame: boolean;
+}
+
+export interface PackInstallResult {
+  filePath: string;
+  packName: string;
+  characters: string[];
+  success: boolean;
+  error?: string;
+}
+
+export type CharacterInstallState = 'installed' | 'notInstalled' | 'differs';
+
+export interface CharacterInstallStatus {
+  name: string;
+  status: CharacterInstallState;
+  packFrames: number;
+  installedFrames: number;
+}
+
+export interface PackInstallStatus {
+  filePath: string;
+  packName: string;
+  characters: CharacterInstallStatus[];
   error?: string;
 }
@@ -3,33 +3,182 @@
 import {main} from '../models';
 import {PackManagement} from '../models';
 import {AnimationEngine} from '../models';
+import {Window} from '../models';
+
+export function ApplyLayout(arg1:string):Promise<void>;
+
+export function BringCharacterToFront(arg1:string):Promise<boolean>;
 
 export function BrowseBfkFile():Promise<string>;
 
+export function BrowseBfkFiles():Promise<Array<string>>;
+
+export function BrowseFramesDir():Promise<string>;
+
+export function CancelInstall(arg1:string):Promise<boolean>;
+
+export function ClearThumbnailCache():Promise<void>;
+
+export function DeleteCharacter(arg1:string):Promise<void>;
+
 export function DestroyAllCharacters():Promise<void>;
 
 export function DestroyCharacter(arg1:string):Promise<boolean>;
 
+export function DuplicateCharacter(arg1:string):Promise<main.CharacterWindowInfo>;
+
+export function ExportCharacterGif(arg1:string,arg2:string,arg3:number):Promise<void>;
+
+export function GetActiveProfile():Promise<string>;
+
 export function GetActiveWindows():Promise<Array<main.CharacterWindowInfo>>;
 
+export function GetBfkPackContents(arg1:string):Promise<Array<PackManagement.PackEntry>>;
+
 export function GetBfkPackInfo(arg1:string):Promise<PackManagement.PackInfo>;
 
+export function GetCharacterFrame(arg1:string):Promise<number>;
+
+export function GetCharacterInfo(arg1:string):Promise<AnimationEngine.CharacterInfo>;
+
+export function GetCharacterPosition(arg1:string):Promise<main.CharacterPosition>;
+
+export function GetCharacterRenderInfo(arg1:string):Promise<Window.RenderInfo>;
+
+export function GetCharacterStates(arg1:string):Promise<Array<string>>;
+
 export function GetCharacters():Promise<Array<AnimationEngine.CharacterInfo>>;
 
+export function GetCharactersSorted(arg1:string):Promise<Array<AnimationEngine.CharacterInfo>>;
+
+export function GetConfigError():Promise<string>;
+
 export function GetConfigPath():Promise<string>;
 
+export function GetDisplays():Promise<Array<Window.DisplayInfo>>;
+
+export function GetFavorites():Promise<Array<string>>;
+
 export function GetFramesPath():Promise<string>;
 
+export function GetInstalledPacks():Promise<Record<string, Array<string>>>;
+
+export function GetMaxWindows():Promise<number>;
+
+export function GetPackInstallStatus(arg1:string):Promise<PackManagement.PackInstallStatus>;
+
+export function GetPowerSaverMode():Promise<boolean>;
+
 export function GetPreviewFrames(arg1:string,arg2:number):Promise<Array<string>>;
 
 export function GetPreviewImageBase64(arg1:string):Promise<string>;
 
+export function GetRecentCharacters():Promise<Array<string>>;
+
+export function GetStartOnBoot():Promise<boolean>;
+
+export function GetTargetFPS():Promise<number>;
+
+export function GetWindowStats(arg1:string):Promise<Window.WindowStats>;
+
+export function HideAllCharacters():Promise<void>;
+
+export function InstallBfkFromURL(arg1:string):Promise<void>;
+
 export function InstallBfkPack(arg1:string):Promise<void>;
 
+export function InstallBfkPackWithOptions(arg1:string,arg2:boolean):Promise<void>;
+
+export function InstallBfkPackWithProgress(arg1:string,arg2:boolean):Promise<string>;
+
+export function InstallBfkPacks(arg1:Array<string>):Promise<Array<main.PackInstallResult>>;
+
+export function ListLayouts():Promise<Array<string>>;
+
+export function ListProfiles():Promise<Array<string>>;
+
 export function OpenConfig():Promise<void>;
 
 export function OpenFramesDir():Promise<void>;
 
+export function PauseAllCharacters():Promise<void>;
+
+export function RenameCharacter(arg1:string,arg2:string):Promise<void>;
+
+export function ResumeAllCharacters():Promise<void>;
+
+export function SaveLayout(arg1:string):Promise<void>;
+
+export function ScreenshotCharacter(arg1:string):Promise<string>;
+
+export function SearchCharacters(arg1:string):Promise<Array<AnimationEngine.CharacterInfo>>;
+
+export function SendCharacterToBack(arg1:string):Promise<boolean>;
+
+export function SetAutoSpawn(arg1:Array<string>):Promise<void>;
+
+export function SetCharacterAlwaysOnTop(arg1:string,arg2:boolean):Promise<boolean>;
+
+export function SetCharacterBackground(arg1:string,arg2:string,arg3:number):Promise<boolean>;
+
+export function SetCharacterBehavior(arg1:string,arg2:string):Promise<boolean>;
+
+export function SetCharacterClickThrough(arg1:string,arg2:boolean):Promise<boolean>;
+
+export function SetCharacterFlip(arg1:string,arg2:boolean):Promise<boolean>;
+
+export function SetCharacterFrame(arg1:string,arg2:number):Promise<boolean>;
+
+export function SetCharacterGravity(arg1:string,arg2:boolean):Promise<boolean>;
+
+export function SetCharacterLocked(arg1:string,arg2:boolean):Promise<boolean>;
+
+export function SetCharacterOpacity(arg1:string,arg2:number):Promise<boolean>;
+
+export function SetCharacterPaused(arg1:string,arg2:boolean):Promise<boolean>;
+
+export function SetCharacterPosition(arg1:string,arg2:number,arg3:number):Promise<boolean>;
+
+export function SetCharacterReverse(arg1:string,arg2:boolean):Promise<boolean>;
+
 export function SetCharacterScale(arg1:string,arg2:number):Promise<boolean>;
 
+export function SetCharacterScaleAnchor(arg1:string,arg2:string):Promise<boolean>;
+
+export function SetCharacterSpeed(arg1:string,arg2:number):Promise<boolean>;
+
+export function SetCharacterState(arg1:string,arg2:string):Promise<boolean>;
+
+export function SetCharacterTint(arg1:string,arg2:string):Promise<boolean>;
+
+export function SetCharacterVisible(arg1:string,arg2:boolean):Promise<boolean>;
+
+export function SetCharacterWindowOpacity(arg1:string,arg2:number):Promise<boolean>;
+
+export function SetFramesPath(arg1:string):Promise<void>;
+
+export function SetGlobalScale(arg1:number):Promise<number>;
+
+export function SetIdleState(arg1:string,arg2:string):Promise<boolean>;
+
+export function SetPowerSaverMode(arg1:boolean):Promise<void>;
+
+export function SetStartOnBoot(arg1:boolean):Promise<void>;
+
+export function SetTargetFPS(arg1:number):Promise<void>;
+
+export function ShowAllCharacters():Promise<void>;
+
 export function SpawnCharacter(arg1:string):Promise<main.CharacterWindowInfo>;
+
+export function SpawnCharacterAtCursor(arg1:string):Promise<main.CharacterWindowInfo>;
+
+export function SpawnCharacterOnDisplay(arg1:string,arg2:number):Promise<main.CharacterWindowInfo>;
+
+export function SpawnCharacters(arg1:Array<string>):Promise<Array<main.CharacterWindowInfo>>;
+
+export function SwitchProfile(arg1:string):Promise<void>;
+
+export function ToggleFavorite(arg1:string):Promise<boolean>;
+
+export function UninstallPack(arg1:string):Promise<void>;
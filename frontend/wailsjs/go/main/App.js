@@ -2,10 +2,38 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function ApplyLayout(arg1) {
+  return window['go']['main']['App']['ApplyLayout'](arg1);
+}
+
+export function BringCharacterToFront(arg1) {
+  return window['go']['main']['App']['BringCharacterToFront'](arg1);
+}
+
 export function BrowseBfkFile() {
   return window['go']['main']['App']['BrowseBfkFile']();
 }
 
+export function BrowseBfkFiles() {
+  return window['go']['main']['App']['BrowseBfkFiles']();
+}
+
+export function BrowseFramesDir() {
+  return window['go']['main']['App']['BrowseFramesDir']();
+}
+
+export function CancelInstall(arg1) {
+  return window['go']['main']['App']['CancelInstall'](arg1);
+}
+
+export function ClearThumbnailCache() {
+  return window['go']['main']['App']['ClearThumbnailCache']();
+}
+
+export function DeleteCharacter(arg1) {
+  return window['go']['main']['App']['DeleteCharacter'](arg1);
+}
+
 export function DestroyAllCharacters() {
   return window['go']['main']['App']['DestroyAllCharacters']();
 }
@@ -14,26 +42,94 @@ export function DestroyCharacter(arg1) {
   return window['go']['main']['App']['DestroyCharacter'](arg1);
 }
 
+export function DuplicateCharacter(arg1) {
+  return window['go']['main']['App']['DuplicateCharacter'](arg1);
+}
+
+export function ExportCharacterGif(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportCharacterGif'](arg1, arg2, arg3);
+}
+
+export function GetActiveProfile() {
+  return window['go']['main']['App']['GetActiveProfile']();
+}
+
 export function GetActiveWindows() {
   return window['go']['main']['App']['GetActiveWindows']();
 }
 
+export function GetBfkPackContents(arg1) {
+  return window['go']['main']['App']['GetBfkPackContents'](arg1);
+}
+
 export function GetBfkPackInfo(arg1) {
   return window['go']['main']['App']['GetBfkPackInfo'](arg1);
 }
 
+export function GetCharacterFrame(arg1) {
+  return window['go']['main']['App']['GetCharacterFrame'](arg1);
+}
+
+export function GetCharacterInfo(arg1) {
+  return window['go']['main']['App']['GetCharacterInfo'](arg1);
+}
+
+export function GetCharacterPosition(arg1) {
+  return window['go']['main']['App']['GetCharacterPosition'](arg1);
+}
+
+export function GetCharacterRenderInfo(arg1) {
+  return window['go']['main']['App']['GetCharacterRenderInfo'](arg1);
+}
+
+export function GetCharacterStates(arg1) {
+  return window['go']['main']['App']['GetCharacterStates'](arg1);
+}
+
 export function GetCharacters() {
   return window['go']['main']['App']['GetCharacters']();
 }
 
+export function GetCharactersSorted(arg1) {
+  return window['go']['main']['App']['GetCharactersSorted'](arg1);
+}
+
+export function GetConfigError() {
+  return window['go']['main']['App']['GetConfigError']();
+}
+
 export function GetConfigPath() {
   return window['go']['main']['App']['GetConfigPath']();
 }
 
+export function GetDisplays() {
+  return window['go']['main']['App']['GetDisplays']();
+}
+
+export function GetFavorites() {
+  return window['go']['main']['App']['GetFavorites']();
+}
+
 export function GetFramesPath() {
   return window['go']['main']['App']['GetFramesPath']();
 }
 
+export function GetInstalledPacks() {
+  return window['go']['main']['App']['GetInstalledPacks']();
+}
+
+export function GetMaxWindows() {
+  return window['go']['main']['App']['GetMaxWindows']();
+}
+
+export function GetPackInstallStatus(arg1) {
+  return window['go']['main']['App']['GetPackInstallStatus'](arg1);
+}
+
+export function GetPowerSaverMode() {
+  return window['go']['main']['App']['GetPowerSaverMode']();
+}
+
 export function GetPreviewFrames(arg1, arg2) {
   return window['go']['main']['App']['GetPreviewFrames'](arg1, arg2);
 }
@@ -42,10 +138,54 @@ export function GetPreviewImageBase64(arg1) {
   return window['go']['main']['App']['GetPreviewImageBase64'](arg1);
 }
 
+export function GetRecentCharacters() {
+  return window['go']['main']['App']['GetRecentCharacters']();
+}
+
+export function GetStartOnBoot() {
+  return window['go']['main']['App']['GetStartOnBoot']();
+}
+
+export function GetTargetFPS() {
+  return window['go']['main']['App']['GetTargetFPS']();
+}
+
+export function GetWindowStats(arg1) {
+  return window['go']['main']['App']['GetWindowStats'](arg1);
+}
+
+export function HideAllCharacters() {
+  return window['go']['main']['App']['HideAllCharacters']();
+}
+
+export function InstallBfkFromURL(arg1) {
+  return window['go']['main']['App']['InstallBfkFromURL'](arg1);
+}
+
 export function InstallBfkPack(arg1) {
   return window['go']['main']['App']['InstallBfkPack'](arg1);
 }
 
+export function InstallBfkPackWithOptions(arg1, arg2) {
+  return window['go']['main']['App']['InstallBfkPackWithOptions'](arg1, arg2);
+}
+
+export function InstallBfkPackWithProgress(arg1, arg2) {
+  return window['go']['main']['App']['InstallBfkPackWithProgress'](arg1, arg2);
+}
+
+export function InstallBfkPacks(arg1) {
+  return window['go']['main']['App']['InstallBfkPacks'](arg1);
+}
+
+export function ListLayouts() {
+  return window['go']['main']['App']['ListLayouts']();
+}
+
+export function ListProfiles() {
+  return window['go']['main']['App']['ListProfiles']();
+}
+
 export function OpenConfig() {
   return window['go']['main']['App']['OpenConfig']();
 }
@@ -54,10 +194,166 @@ export function OpenFramesDir() {
   return window['go']['main']['App']['OpenFramesDir']();
 }
 
+export function PauseAllCharacters() {
+  return window['go']['main']['App']['PauseAllCharacters']();
+}
+
+export function RenameCharacter(arg1, arg2) {
+  return window['go']['main']['App']['RenameCharacter'](arg1, arg2);
+}
+
+export function ResumeAllCharacters() {
+  return window['go']['main']['App']['ResumeAllCharacters']();
+}
+
+export function SaveLayout(arg1) {
+  return window['go']['main']['App']['SaveLayout'](arg1);
+}
+
+export function ScreenshotCharacter(arg1) {
+  return window['go']['main']['App']['ScreenshotCharacter'](arg1);
+}
+
+export function SearchCharacters(arg1) {
+  return window['go']['main']['App']['SearchCharacters'](arg1);
+}
+
+export function SendCharacterToBack(arg1) {
+  return window['go']['main']['App']['SendCharacterToBack'](arg1);
+}
+
+export function SetAutoSpawn(arg1) {
+  return window['go']['main']['App']['SetAutoSpawn'](arg1);
+}
+
+export function SetCharacterAlwaysOnTop(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterAlwaysOnTop'](arg1, arg2);
+}
+
+export function SetCharacterBackground(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetCharacterBackground'](arg1, arg2, arg3);
+}
+
+export function SetCharacterBehavior(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterBehavior'](arg1, arg2);
+}
+
+export function SetCharacterClickThrough(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterClickThrough'](arg1, arg2);
+}
+
+export function SetCharacterFlip(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterFlip'](arg1, arg2);
+}
+
+export function SetCharacterFrame(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterFrame'](arg1, arg2);
+}
+
+export function SetCharacterGravity(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterGravity'](arg1, arg2);
+}
+
+export function SetCharacterLocked(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterLocked'](arg1, arg2);
+}
+
+export function SetCharacterOpacity(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterOpacity'](arg1, arg2);
+}
+
+export function SetCharacterPaused(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterPaused'](arg1, arg2);
+}
+
+export function SetCharacterPosition(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetCharacterPosition'](arg1, arg2, arg3);
+}
+
+export function SetCharacterReverse(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterReverse'](arg1, arg2);
+}
+
 export function SetCharacterScale(arg1, arg2) {
   return window['go']['main']['App']['SetCharacterScale'](arg1, arg2);
 }
 
+export function SetCharacterScaleAnchor(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterScaleAnchor'](arg1, arg2);
+}
+
+export function SetCharacterSpeed(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterSpeed'](arg1, arg2);
+}
+
+export function SetCharacterState(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterState'](arg1, arg2);
+}
+
+export function SetCharacterTint(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterTint'](arg1, arg2);
+}
+
+export function SetCharacterVisible(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterVisible'](arg1, arg2);
+}
+
+export function SetCharacterWindowOpacity(arg1, arg2) {
+  return window['go']['main']['App']['SetCharacterWindowOpacity'](arg1, arg2);
+}
+
+export function SetFramesPath(arg1) {
+  return window['go']['main']['App']['SetFramesPath'](arg1);
+}
+
+export function SetGlobalScale(arg1) {
+  return window['go']['main']['App']['SetGlobalScale'](arg1);
+}
+
+export function SetIdleState(arg1, arg2) {
+  return window['go']['main']['App']['SetIdleState'](arg1, arg2);
+}
+
+export function SetPowerSaverMode(arg1) {
+  return window['go']['main']['App']['SetPowerSaverMode'](arg1);
+}
+
+export function SetStartOnBoot(arg1) {
+  return window['go']['main']['App']['SetStartOnBoot'](arg1);
+}
+
+export function SetTargetFPS(arg1) {
+  return window['go']['main']['App']['SetTargetFPS'](arg1);
+}
+
+export function ShowAllCharacters() {
+  return window['go']['main']['App']['ShowAllCharacters']();
+}
+
 export function SpawnCharacter(arg1) {
   return window['go']['main']['App']['SpawnCharacter'](arg1);
 }
+
+export function SpawnCharacterAtCursor(arg1) {
+  return window['go']['main']['App']['SpawnCharacterAtCursor'](arg1);
+}
+
+export function SpawnCharacterOnDisplay(arg1, arg2) {
+  return window['go']['main']['App']['SpawnCharacterOnDisplay'](arg1, arg2);
+}
+
+export function SpawnCharacters(arg1) {
+  return window['go']['main']['App']['SpawnCharacters'](arg1);
+}
+
+export function SwitchProfile(arg1) {
+  return window['go']['main']['App']['SwitchProfile'](arg1);
+}
+
+export function ToggleFavorite(arg1) {
+  return window['go']['main']['App']['ToggleFavorite'](arg1);
+}
+
+export function UninstallPack(arg1) {
+  return window['go']['main']['App']['UninstallPack'](arg1);
+}
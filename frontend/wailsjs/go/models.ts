@@ -5,6 +5,13 @@ export namespace AnimationEngine {
 	    path: string;
 	    previewPath: string;
 	    frameCount: number;
+	    fps: number;
+	    states?: string[];
+	    displayName: string;
+	    author?: string;
+	    description?: string;
+	    isFavorite: boolean;
+	    valid: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new CharacterInfo(source);
@@ -16,6 +23,13 @@ export namespace AnimationEngine {
 	        this.path = source["path"];
 	        this.previewPath = source["previewPath"];
 	        this.frameCount = source["frameCount"];
+	        this.fps = source["fps"];
+	        this.states = source["states"];
+	        this.displayName = source["displayName"];
+	        this.author = source["author"];
+	        this.description = source["description"];
+	        this.isFavorite = source["isFavorite"];
+	        this.valid = source["valid"];
 	    }
 	}
 
@@ -23,11 +37,53 @@ export namespace AnimationEngine {
 
 export namespace PackManagement {
 	
+	export class CharacterInstallStatus {
+	    name: string;
+	    status: string;
+	    packFrames: number;
+	    installedFrames: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CharacterInstallStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.status = source["status"];
+	        this.packFrames = source["packFrames"];
+	        this.installedFrames = source["installedFrames"];
+	    }
+	}
+	export class PackEntry {
+	    name: string;
+	    size: number;
+	    isDir: boolean;
+	    isFrame: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PackEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.size = source["size"];
+	        this.isDir = source["isDir"];
+	        this.isFrame = source["isFrame"];
+	    }
+	}
 	export class PackInfo {
 	    filePath: string;
 	    packName: string;
 	    characters: string[];
+	    frameCounts: Record<string, number>;
 	    previewImage: string;
+	    characterPreviews: Record<string, string>;
+	    author?: string;
+	    version?: string;
+	    description?: string;
+	    conflicts?: string[];
 	    error?: string;
 	
 	    static createFrom(source: any = {}) {
@@ -39,20 +95,147 @@ export namespace PackManagement {
 	        this.filePath = source["filePath"];
 	        this.packName = source["packName"];
 	        this.characters = source["characters"];
+	        this.frameCounts = source["frameCounts"];
 	        this.previewImage = source["previewImage"];
+	        this.characterPreviews = source["characterPreviews"];
+	        this.author = source["author"];
+	        this.version = source["version"];
+	        this.description = source["description"];
+	        this.conflicts = source["conflicts"];
 	        this.error = source["error"];
 	    }
 	}
+	export class PackInstallStatus {
+	    filePath: string;
+	    packName: string;
+	    characters: CharacterInstallStatus[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PackInstallStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filePath = source["filePath"];
+	        this.packName = source["packName"];
+	        this.characters = this.convertValues(source["characters"], CharacterInstallStatus);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace Window {
+	
+	export class DisplayInfo {
+	    index: number;
+	    name: string;
+	    x: number;
+	    y: number;
+	    width: number;
+	    height: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DisplayInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.index = source["index"];
+	        this.name = source["name"];
+	        this.x = source["x"];
+	        this.y = source["y"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	    }
+	}
+	export class RenderInfo {
+	    frameIndex: number;
+	    frameCount: number;
+	    width: number;
+	    height: number;
+	    scale: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RenderInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.frameIndex = source["frameIndex"];
+	        this.frameCount = source["frameCount"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	        this.scale = source["scale"];
+	    }
+	}
+	export class WindowStats {
+	    fps: number;
+	    frameCount: number;
+	    textureCount: number;
+	    vramBytes: number;
+	    renderer: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WindowStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.fps = source["fps"];
+	        this.frameCount = source["frameCount"];
+	        this.textureCount = source["textureCount"];
+	        this.vramBytes = source["vramBytes"];
+	        this.renderer = source["renderer"];
+	    }
+	}
 
 }
 
 export namespace main {
 	
+	export class CharacterPosition {
+	    x: number;
+	    y: number;
+	    ok: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CharacterPosition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.x = source["x"];
+	        this.y = source["y"];
+	        this.ok = source["ok"];
+	    }
+	}
 	export class CharacterWindowInfo {
 	    id: string;
 	    characterName: string;
 	    isRunning: boolean;
 	    scale: number;
+	    visible: boolean;
+	    error?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new CharacterWindowInfo(source);
@@ -64,6 +247,28 @@ export namespace main {
 	        this.characterName = source["characterName"];
 	        this.isRunning = source["isRunning"];
 	        this.scale = source["scale"];
+	        this.visible = source["visible"];
+	        this.error = source["error"];
+	    }
+	}
+	export class PackInstallResult {
+	    filePath: string;
+	    packName: string;
+	    characters: string[];
+	    success: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PackInstallResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filePath = source["filePath"];
+	        this.packName = source["packName"];
+	        this.characters = source["characters"];
+	        this.success = source["success"];
+	        this.error = source["error"];
 	    }
 	}
 
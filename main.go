@@ -7,7 +7,6 @@ Initializes SDL and starts Wails application with React frontend.
 */
 
 import (
-	"context"
 	"embed"
 	"fmt"
 
@@ -42,9 +41,7 @@ func main() {
 		},
 		BackgroundColour: &options.RGBA{R: 24, G: 24, B: 27, A: 1},
 		OnStartup:        app.startup,
-		OnShutdown: func(ctx context.Context) {
-			app.DestroyAllCharacters()
-		},
+		OnShutdown:       app.shutdown,
 		Bind: []interface{}{
 			app,
 		},